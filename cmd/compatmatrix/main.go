@@ -0,0 +1,69 @@
+// Command compatmatrix writes the compatibility matrix of layout tokens
+// across dialects and backends as Markdown or HTML.
+//
+// Usage:
+//
+//	compatmatrix [-html] [-o FILE]
+//
+// The columns are those of timeformat.DefaultColumns. Build with the glibc
+// tag to add a column checked against the C library.
+package main
+
+//go:generate go run -tags glibc . -o ../../COMPATIBILITY.md
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"timeformattest/timeformat"
+	_ "timeformattest/timeparse" // registers the compiled backend
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+const header = `# Compatibility matrix
+
+Generated by cmd/compatmatrix; do not edit.
+
+Each row is a token of a Go layout, each column a dialect. A cell shows
+the spelling of the token in the dialect and whether the backend of the
+column formats it like timeformat and parses it back. N/A means there is
+no exact spelling or the backend supports neither.
+
+`
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("compatmatrix", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asHTML := fs.Bool("html", false, "write an HTML table instead of Markdown")
+	out := fs.String("o", "", "file to write, default standard output")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintln(stderr, "usage: compatmatrix [-html] [-o FILE]")
+		return 2
+	}
+	m, err := timeformat.Compatibility(timeformat.DefaultColumns())
+	if err != nil {
+		fmt.Fprintln(stderr, "compatmatrix:", err)
+		return 1
+	}
+	text := header + m.Markdown()
+	if *asHTML {
+		text = m.HTML()
+	}
+	if *out == "" {
+		io.WriteString(stdout, text)
+		return 0
+	}
+	if err := os.WriteFile(*out, []byte(text), 0o666); err != nil {
+		fmt.Fprintln(stderr, "compatmatrix:", err)
+		return 1
+	}
+	return 0
+}
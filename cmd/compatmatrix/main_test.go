@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	testData := []struct {
+		Args     []string
+		Contains string
+		Code     int
+	}{
+		{Contains: "| `2006` | four-digit year | `2006` format, parse | `2006` format, parse | `%Y` format, parse |"},
+		{Args: []string{"-html"}, Contains: "<th>timeformat</th>"},
+		{Args: []string{"extra"}, Code: 2},
+	}
+	for _, test := range testData {
+		var stdout, stderr bytes.Buffer
+		code := run(test.Args, &stdout, &stderr)
+		if code != test.Code || !strings.Contains(stdout.String(), test.Contains) {
+			t.Errorf("run %q exit %d, want %d, output contains %q\n%s%s", test.Args, code, test.Code, test.Contains, stdout.String(), stderr.String())
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Command fixturegen writes the Go table mirroring a format fixture file.
+//
+// Usage:
+//
+//	fixturegen -in FILE -out FILE [-package NAME] [-var NAME]
+//
+// The table has the fields Timestamp, GoLayout, StrftimeLayout and
+// Expected. Groups of the fixture become comments above their cases and
+// notes comments inside them; zones are loaded with the test helper
+// location.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"timeformattest/fixture"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+func run(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("fixturegen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	in := fs.String("in", "", "fixture file to read")
+	out := fs.String("out", "", "Go file to write")
+	pkg := fs.String("package", "timeformat", "package of the Go file")
+	name := fs.String("var", "formatTests", "name of the table variable")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *in == "" || *out == "" || fs.NArg() > 0 {
+		fmt.Fprintln(stderr, "usage: fixturegen -in FILE -out FILE [-package NAME] [-var NAME]")
+		return 2
+	}
+	cases, err := fixture.LoadFile(*in)
+	if err != nil {
+		fmt.Fprintln(stderr, "fixturegen:", err)
+		return 1
+	}
+	src, err := generate(cases, filepath.ToSlash(*in), *pkg, *name)
+	if err != nil {
+		fmt.Fprintln(stderr, "fixturegen:", err)
+		return 1
+	}
+	if err := os.WriteFile(*out, src, 0o666); err != nil {
+		fmt.Fprintln(stderr, "fixturegen:", err)
+		return 1
+	}
+	return 0
+}
+
+// generate returns the formatted Go source of the table.
+func generate(cases []fixture.Case, in, pkg, name string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by fixturegen from %s; DO NOT EDIT.\n\n", in)
+	fmt.Fprintf(&b, "package %s\n\nimport \"time\"\n\n", pkg)
+	fmt.Fprintf(&b, "// %s mirrors %s.\n", name, in)
+	fmt.Fprintf(&b, "var %s = []struct {\n\tTimestamp time.Time\n\tGoLayout string\n\tStrftimeLayout string\n\tExpected string\n}{\n", name)
+	group := ""
+	for i, c := range cases {
+		if c.Group != group {
+			group = c.Group
+			fmt.Fprintf(&b, "// %s\n", group)
+		}
+		ts, err := c.Instant()
+		if err != nil {
+			return nil, fmt.Errorf("case %d: %w", i+1, err)
+		}
+		b.WriteString("{\n")
+		if c.Note != "" {
+			fmt.Fprintf(&b, "// %s\n", c.Note)
+		}
+		fmt.Fprintf(&b, "Timestamp: %s,\n", date(ts, c.Zone))
+		fmt.Fprintf(&b, "GoLayout: %s,\n", strconv.Quote(c.Layout))
+		if c.Strftime != "" {
+			fmt.Fprintf(&b, "StrftimeLayout: %s,\n", strconv.Quote(c.Strftime))
+		}
+		fmt.Fprintf(&b, "Expected: %s,\n", strconv.Quote(c.Output))
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+// date returns the time.Date call for t.
+func date(t time.Time, zone string) string {
+	loc := "time.UTC"
+	if zone != "" {
+		loc = "location(" + strconv.Quote(zone) + ")"
+	} else if _, offset := t.Zone(); offset != 0 {
+		loc = "time.FixedZone(\"\", " + strconv.Itoa(offset) + ")"
+	}
+	return fmt.Sprintf("time.Date(%d, %d, %d, %d, %d, %d, %d, %s)",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"timeformattest/fixture"
+)
+
+func TestGenerate(t *testing.T) {
+	cases := []fixture.Case{
+		{Group: "year", Time: "2021-01-01T00:00:00Z", Layout: "2006", Strftime: "%Y", Output: "2021"},
+		{Group: "year", Time: "0001-01-01T00:00:00+08:00", Layout: "06", Output: "01", Note: "no strftime equivalent"},
+		{Group: "zone", Time: "2021-01-01T01:01:01+08:00", Zone: "Asia/Shanghai", Layout: "MST", Output: "CST"},
+	}
+	want := `// Code generated by fixturegen from cases.jsonl; DO NOT EDIT.
+
+package p
+
+import "time"
+
+// tests mirrors cases.jsonl.
+var tests = []struct {
+	Timestamp      time.Time
+	GoLayout       string
+	StrftimeLayout string
+	Expected       string
+}{
+	// year
+	{
+		Timestamp:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		GoLayout:       "2006",
+		StrftimeLayout: "%Y",
+		Expected:       "2021",
+	},
+	{
+		// no strftime equivalent
+		Timestamp: time.Date(1, 1, 1, 0, 0, 0, 0, time.FixedZone("", 28800)),
+		GoLayout:  "06",
+		Expected:  "01",
+	},
+	// zone
+	{
+		Timestamp: time.Date(2021, 1, 1, 1, 1, 1, 0, location("Asia/Shanghai")),
+		GoLayout:  "MST",
+		Expected:  "CST",
+	},
+}
+`
+	got, err := generate(cases, "cases.jsonl", "p", "tests")
+	if err != nil || string(got) != want {
+		t.Errorf("generate\nwant=%s\ngot= %s %v", want, got, err)
+	}
+}
+
+// TestMirrorUpToDate checks that the generated table of timeformat matches
+// its fixture file.
+func TestMirrorUpToDate(t *testing.T) {
+	cases, err := fixture.LoadFile("../../timeformat/testdata/format.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := generate(cases, "testdata/format.jsonl", "timeformat", "formatTests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../timeformat/format_cases_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("timeformat/format_cases_test.go is out of date, run go generate ./timeformat")
+	}
+}
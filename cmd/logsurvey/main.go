@@ -0,0 +1,205 @@
+// Command logsurvey reports which timestamp layouts occur in log files.
+//
+// Usage:
+//
+//	logsurvey [-json] [-examples N] [-samples N] [FILE...]
+//
+// Every line is classified by the layout of the first timestamp found in it;
+// standard input is read when no files are given. The report counts lines
+// per layout with example lines, and keeps samples of lines in which no
+// timestamp was found. Lines longer than 1 MB count as lines without a
+// timestamp.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"timeformattest/timeparse"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// Report is the outcome of a survey.
+type Report struct {
+	Lines     int      `json:"lines"`
+	Layouts   []*Count `json:"layouts"` // most frequent first
+	Unmatched int      `json:"unmatched"`
+	Samples   []string `json:"unmatched_samples"`
+}
+
+// Count counts the lines with timestamps in one layout.
+type Count struct {
+	Name     string   `json:"name"`
+	Layout   string   `json:"layout"`
+	Lines    int      `json:"lines"`
+	Examples []string `json:"examples"`
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("logsurvey", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	examples := fs.Int("examples", 3, "example lines to keep per layout")
+	samples := fs.Int("samples", 10, "lines without a timestamp to keep")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	s := &survey{examples: *examples, samples: *samples, counts: map[string]*Count{}}
+	if fs.NArg() == 0 {
+		if err := s.scan(stdin); err != nil {
+			fmt.Fprintln(stderr, "logsurvey:", err)
+			return 2
+		}
+	}
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintln(stderr, "logsurvey:", err)
+			return 2
+		}
+		err = s.scan(f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(stderr, "logsurvey: %s: %v\n", name, err)
+			return 2
+		}
+	}
+
+	r := s.report()
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r); err != nil {
+			fmt.Fprintln(stderr, "logsurvey:", err)
+			return 2
+		}
+		return 0
+	}
+	write(stdout, r)
+	return 0
+}
+
+// survey accumulates the classification of lines.
+type survey struct {
+	examples, samples int
+	lines, unmatched  int
+	counts            map[string]*Count
+	unmatchedSamples  []string
+}
+
+// maxLine bounds the length of lines searched for timestamps. Longer lines
+// count as unmatched and are sampled up to sampleLine bytes.
+const (
+	maxLine    = 1 << 20
+	sampleLine = 200
+)
+
+func (s *survey) scan(r io.Reader) error {
+	br := bufio.NewReaderSize(r, maxLine)
+	for {
+		line, err := br.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			sample := string(line[:sampleLine]) + "..."
+			for err == bufio.ErrBufferFull {
+				_, err = br.ReadSlice('\n')
+			}
+			s.lines++
+			s.unmatch(sample)
+		} else if len(line) > 0 {
+			line = bytes.TrimSuffix(bytes.TrimSuffix(line, []byte("\n")), []byte("\r"))
+			s.add(string(line))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *survey) add(line string) {
+	s.lines++
+	matches := timeparse.FindAll(line)
+	if len(matches) == 0 {
+		s.unmatch(line)
+		return
+	}
+	m := matches[0]
+	c := s.counts[m.Name]
+	if c == nil {
+		c = &Count{Name: m.Name, Layout: m.Layout, Examples: []string{}}
+		s.counts[m.Name] = c
+	}
+	c.Lines++
+	if len(c.Examples) < s.examples {
+		c.Examples = append(c.Examples, line)
+	}
+}
+
+// unmatch counts a line without a timestamp.
+func (s *survey) unmatch(line string) {
+	s.unmatched++
+	if len(s.unmatchedSamples) < s.samples {
+		s.unmatchedSamples = append(s.unmatchedSamples, line)
+	}
+}
+
+func (s *survey) report() *Report {
+	r := &Report{Lines: s.lines, Layouts: []*Count{}, Unmatched: s.unmatched, Samples: s.unmatchedSamples}
+	for _, c := range s.counts {
+		r.Layouts = append(r.Layouts, c)
+	}
+	sort.Slice(r.Layouts, func(i, j int) bool {
+		a, b := r.Layouts[i], r.Layouts[j]
+		if a.Lines != b.Lines {
+			return a.Lines > b.Lines
+		}
+		return a.Name < b.Name
+	})
+	if r.Samples == nil {
+		r.Samples = []string{}
+	}
+	return r
+}
+
+// write prints the report as a table followed by the examples.
+func write(w io.Writer, r *Report) {
+	percent := func(n int) float64 {
+		if r.Lines == 0 {
+			return 0
+		}
+		return 100 * float64(n) / float64(r.Lines)
+	}
+	fmt.Fprintf(w, "%8s %6s  %s\n", "lines", "%", "layout")
+	for _, c := range r.Layouts {
+		fmt.Fprintf(w, "%8d %6.1f  %s %q\n", c.Lines, percent(c.Lines), c.Name, c.Layout)
+	}
+	fmt.Fprintf(w, "%8d %6.1f  no timestamp\n", r.Unmatched, percent(r.Unmatched))
+	fmt.Fprintf(w, "%8d %6s  total\n", r.Lines, "")
+	for _, c := range r.Layouts {
+		if len(c.Examples) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n", c.Name)
+		for _, e := range c.Examples {
+			fmt.Fprintf(w, "  %s\n", e)
+		}
+	}
+	if len(r.Samples) > 0 {
+		fmt.Fprintf(w, "\nno timestamp:\n")
+		for _, e := range r.Samples {
+			fmt.Fprintf(w, "  %s\n", e)
+		}
+	}
+}
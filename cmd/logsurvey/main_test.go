@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const logs = `2021-03-07T14:05:09Z start
+Mar  7 14:05:10 host cron[1]: run
+2021-03-07T14:05:11.5+01:00 done
+    at main.go:12
+10.0.0.1 - - [07/Mar/2021:14:05:09 -0500] "GET /" 200
+2021-03-07T14:05:12Z stop
+`
+
+func TestRunJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-json", "-examples", "2"}, strings.NewReader(logs), &stdout, &stderr); code != 0 {
+		t.Fatalf("run exit %d: %s", code, stderr.String())
+	}
+	var got Report
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := Report{
+		Lines: 6,
+		Layouts: []*Count{
+			{Name: "RFC3339", Layout: "2006-01-02T15:04:05Z07:00", Lines: 3, Examples: []string{"2021-03-07T14:05:09Z start", "2021-03-07T14:05:11.5+01:00 done"}},
+			{Name: "Apache CLF", Layout: "02/Jan/2006:15:04:05 -0700", Lines: 1, Examples: []string{`10.0.0.1 - - [07/Mar/2021:14:05:09 -0500] "GET /" 200`}},
+			{Name: "syslog", Layout: "Jan _2 15:04:05", Lines: 1, Examples: []string{"Mar  7 14:05:10 host cron[1]: run"}},
+		},
+		Unmatched: 1,
+		Samples:   []string{"    at main.go:12"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("run -json\nwant=%s\ngot= %s", mustJSON(want), stdout.String())
+	}
+}
+
+func TestRunTable(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-examples", "0", "-samples", "0"}, strings.NewReader(logs), &stdout, &stderr); code != 0 {
+		t.Fatalf("run exit %d: %s", code, stderr.String())
+	}
+	want := `   lines      %  layout
+       3   50.0  RFC3339 "2006-01-02T15:04:05Z07:00"
+       1   16.7  Apache CLF "02/Jan/2006:15:04:05 -0700"
+       1   16.7  syslog "Jan _2 15:04:05"
+       1   16.7  no timestamp
+       6         total
+`
+	if got := stdout.String(); got != want {
+		t.Errorf("run\nwant=\n%s\ngot=\n%s", want, got)
+	}
+}
+
+func mustJSON(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func TestRunLongLine(t *testing.T) {
+	long := "2021-03-07T14:05:09Z " + strings.Repeat("x", 2<<20)
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-json"}, strings.NewReader(long+"\n2021-03-07T14:05:12Z stop\n"), &stdout, &stderr); code != 0 {
+		t.Fatalf("run exit %d: %s", code, stderr.String())
+	}
+	var got Report
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Lines != 2 || got.Unmatched != 1 || len(got.Layouts) != 1 || got.Layouts[0].Lines != 1 {
+		t.Errorf("run -json: %d lines, %d unmatched, layouts %s; want 2, 1 and one RFC3339 line", got.Lines, got.Unmatched, mustJSON(got.Layouts))
+	}
+	if want := long[:200] + "..."; len(got.Samples) != 1 || got.Samples[0] != want {
+		t.Errorf("run -json: samples %.300q, want %.300q", got.Samples, want)
+	}
+}
@@ -0,0 +1,325 @@
+// Command retime rewrites the timestamps in a stream of log lines from one
+// layout to another.
+//
+// Usage:
+//
+//	retime -in LAYOUT [-in LAYOUT...] -out LAYOUT [-field N...|-match REGEXP] [flags] < in > out
+//
+// Timestamps are looked for at whitespace-separated field positions, 1 by
+// default, or wherever a regular expression matches; the first capturing
+// group, if any, is the timestamp. A timestamp may span several fields when
+// its layout contains spaces or pads values with them. Lines without a timestamp are copied as they
+// are.
+//
+// Flags:
+//
+//	-from DIALECT   dialect of the -in layouts, go by default
+//	-to DIALECT     dialect of the -out layout, go by default
+//	-in-zone NAME   zone of timestamps that carry no offset, UTC by default
+//	-zone NAME      convert timestamps to this zone before formatting
+//	-strict         exit with status 1 if some line had no timestamp
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"timeformattest/timeformat"
+	"timeformattest/timeparse"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// list is a repeatable string flag.
+type list []string
+
+func (l *list) String() string     { return strings.Join(*l, ", ") }
+func (l *list) Set(s string) error { *l = append(*l, s); return nil }
+
+// fields is a repeatable flag of 1-based field positions.
+type fields []int
+
+func (f *fields) String() string { return fmt.Sprint(*f) }
+func (f *fields) Set(s string) error {
+	var n int
+	if _, err := fmt.Sscan(s, &n); err != nil || n < 1 {
+		return errors.New("field positions start at 1")
+	}
+	*f = append(*f, n)
+	return nil
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		in                list
+		out, from, to     string
+		match             string
+		inZone, zone      string
+		strict            bool
+		positions         fields
+		inDialect, outDia *timeformat.Dialect
+	)
+	fs := flag.NewFlagSet("retime", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Var(&in, "in", "layout of the timestamps, repeat to try several")
+	fs.StringVar(&out, "out", "", "layout to rewrite timestamps to")
+	fs.StringVar(&from, "from", "go", "dialect of the -in layouts")
+	fs.StringVar(&to, "to", "go", "dialect of the -out layout")
+	fs.Var(&positions, "field", "1-based position of the field a timestamp starts at, repeatable")
+	fs.StringVar(&match, "match", "", "regular expression locating timestamps")
+	fs.StringVar(&inZone, "in-zone", "", "zone of timestamps without an offset")
+	fs.StringVar(&zone, "zone", "", "zone to convert timestamps to")
+	fs.BoolVar(&strict, "strict", false, "exit with status 1 if some line had no timestamp")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	fail := func(format string, args ...any) int {
+		fmt.Fprintf(stderr, "retime: "+format+"\n", args...)
+		return 2
+	}
+	if len(in) == 0 || out == "" || fs.NArg() > 0 {
+		return fail("want -in and -out layouts and no arguments")
+	}
+	if match != "" && len(positions) > 0 {
+		return fail("-field and -match exclude each other")
+	}
+	if inDialect = timeformat.LookupDialect(from); inDialect == nil {
+		return fail("unknown dialect %q", from)
+	}
+	if outDia = timeformat.LookupDialect(to); outDia == nil {
+		return fail("unknown dialect %q", to)
+	}
+
+	r := &rewriter{}
+	for _, pattern := range in {
+		layout, diags := inDialect.From(pattern)
+		for _, d := range diags {
+			fmt.Fprintf(stderr, "retime: -in %q: %v\n", pattern, d)
+		}
+		r.in = append(r.in, timeparse.Compile(layout))
+		r.spans = append(r.spans, spans(layout))
+	}
+	layout, diags := outDia.From(out)
+	for _, d := range diags {
+		fmt.Fprintf(stderr, "retime: -out %q: %v\n", out, d)
+	}
+	r.out = timeformat.Compile(layout)
+	if match != "" {
+		re, err := regexp.Compile(match)
+		if err != nil {
+			return fail("%v", err)
+		}
+		r.match = re
+	} else {
+		if len(positions) == 0 {
+			positions = fields{1}
+		}
+		sort.Ints(positions)
+		r.fields = positions
+	}
+	var err error
+	if inZone != "" {
+		if r.inZone, err = time.LoadLocation(inZone); err != nil {
+			return fail("%v", err)
+		}
+	}
+	if zone != "" {
+		if r.zone, err = time.LoadLocation(zone); err != nil {
+			return fail("%v", err)
+		}
+	}
+
+	missed, err := r.copy(stdout, stdin)
+	if err != nil {
+		return fail("%v", err)
+	}
+	if strict && missed > 0 {
+		fmt.Fprintf(stderr, "retime: %d lines without a timestamp\n", missed)
+		return 1
+	}
+	return 0
+}
+
+// rewriter rewrites the timestamps of one line at a time.
+type rewriter struct {
+	in     []*timeparse.Layout
+	spans  []span // the number of fields a value of each input layout spans
+	out    *timeformat.Layout
+	fields []int // sorted field positions, or
+	match  *regexp.Regexp
+	inZone *time.Location
+	zone   *time.Location
+}
+
+// copy rewrites the lines of src to dst and returns the number of lines in
+// which no timestamp was found. Input is read in chunks of whole lines,
+// each converted to a string once, so values are parsed without copying.
+func (r *rewriter) copy(dst io.Writer, src io.Reader) (missed int, err error) {
+	chunk := make([]byte, 64<<10)
+	var buf []byte
+	n := 0 // bytes of an incomplete line kept from the last read
+	for {
+		m, err := src.Read(chunk[n:])
+		n += m
+		end := n
+		if err == nil {
+			// the bytes kept from before hold no newline
+			i := bytes.LastIndexByte(chunk[n-m:n], '\n')
+			if i < 0 {
+				if n == len(chunk) {
+					chunk = append(chunk, make([]byte, len(chunk))...)
+				}
+				continue
+			}
+			end = n - m + i + 1
+		}
+		buf = buf[:0]
+		for text := string(chunk[:end]); text != ""; {
+			line := text
+			if i := strings.IndexByte(text, '\n'); i >= 0 {
+				line, text = text[:i+1], text[i+1:]
+			} else {
+				text = ""
+			}
+			end := len(line)
+			for end > 0 && (line[end-1] == '\n' || line[end-1] == '\r') {
+				end--
+			}
+			var ok bool
+			if buf, ok = r.rewrite(buf, line[:end]); !ok {
+				missed++
+			}
+			buf = append(buf, line[end:]...)
+		}
+		if _, err := dst.Write(buf); err != nil {
+			return missed, err
+		}
+		n = copy(chunk, chunk[end:n])
+		if err == io.EOF {
+			return missed, nil
+		}
+		if err != nil {
+			return missed, err
+		}
+	}
+}
+
+// rewrite appends line with its timestamps rewritten to dst and reports
+// whether any timestamp was found.
+func (r *rewriter) rewrite(dst []byte, line string) ([]byte, bool) {
+	found := false
+	last := 0
+	replace := func(start, end int, t time.Time) {
+		if r.zone != nil {
+			t = t.In(r.zone)
+		}
+		dst = append(dst, line[last:start]...)
+		dst = r.out.AppendFormat(dst, t)
+		last = end
+		found = true
+	}
+
+	if r.match != nil {
+		for _, m := range r.match.FindAllStringSubmatchIndex(line, -1) {
+			start, end := m[0], m[1]
+			if len(m) >= 4 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			if start < last {
+				continue
+			}
+			for _, l := range r.in {
+				if t, err := r.parse(l, line[start:end]); err == nil {
+					replace(start, end, t)
+					break
+				}
+			}
+		}
+		return append(dst, line[last:]...), found
+	}
+
+	at, n := 0, 0 // the end of the nth field
+	for _, field := range r.fields {
+		start := -1
+		for n < field {
+			s, e := nextField(line, at)
+			if s == len(line) {
+				break
+			}
+			start, at, n = s, e, n+1
+		}
+		if n < field || start < last {
+			continue
+		}
+	layouts:
+		for j, l := range r.in {
+			for k := r.spans[j].most; k >= max(r.spans[j].least, 1); k-- {
+				end := at
+				for i := 1; i < k && end < len(line); i++ {
+					_, end = nextField(line, end)
+				}
+				if t, err := r.parse(l, line[start:end]); err == nil {
+					replace(start, end, t)
+					break layouts
+				}
+			}
+		}
+	}
+	return append(dst, line[last:]...), found
+}
+
+// span is the least and the most fields a value of a layout spans.
+type span struct{ least, most int }
+
+// spanSamples have one- and two-digit days, days of the year and hours, so
+// that padding with spaces shows in the fields of their values.
+var spanSamples = []time.Time{
+	time.Date(2021, 1, 7, 9, 5, 9, 0, time.UTC),
+	time.Date(2021, 3, 17, 14, 35, 59, 0, time.UTC),
+	time.Date(2021, 12, 24, 23, 59, 59, 0, time.UTC),
+}
+
+// spans returns how many fields the values of layout span.
+func spans(layout string) span {
+	l := timeformat.Compile(layout)
+	s := span{least: math.MaxInt}
+	for _, t := range spanSamples {
+		value, n := l.Format(t), 0
+		for start, end := nextField(value, 0); start < len(value); start, end = nextField(value, end) {
+			n++
+		}
+		s.least, s.most = min(s.least, n), max(s.most, n)
+	}
+	return s
+}
+
+func (r *rewriter) parse(l *timeparse.Layout, value string) (time.Time, error) {
+	if r.inZone != nil {
+		return l.ParseInLocation(value, r.inZone)
+	}
+	return l.Parse(value)
+}
+
+// nextField returns where the first whitespace-separated field of line at
+// or after i starts and ends, both len(line) if there is none.
+func nextField(line string, i int) (start, end int) {
+	for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+	start = i
+	for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+		i++
+	}
+	return start, i
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	testData := []struct {
+		Args  []string
+		Input string
+		Want  string
+		Code  int
+	}{
+		{
+			Args:  []string{"-in", "2006-01-02T15:04:05Z07:00", "-out", "Jan _2 15:04:05"},
+			Input: "2021-03-07T14:05:09Z GET /\nno timestamp here\n2021-03-07T14:05:10+01:00 POST /x\n",
+			Want:  "Mar  7 14:05:09 GET /\nno timestamp here\nMar  7 14:05:10 POST /x\n",
+		},
+		{
+			Args:  []string{"-in", "2006-01-02 15:04:05", "-out", "%s", "-to", "strftime", "-field", "2"},
+			Input: "host1 2021-03-07 14:05:09 ok\r\n",
+			Want:  "host1 1615125909 ok\r\n",
+		},
+		{
+			Args:  []string{"-in", "02/Jan/2006:15:04:05 -0700", "-in", "2006-01-02T15:04:05Z07:00", "-out", time3339, "-zone", "UTC", "-match", `\[([^]]+)\]`},
+			Input: `1.2.3.4 - - [07/Mar/2021:14:05:09 +0100] "GET /" at [2021-03-07T14:05:10+01:00]` + "\n",
+			Want:  `1.2.3.4 - - [2021-03-07T13:05:09Z] "GET /" at [2021-03-07T13:05:10Z]` + "\n",
+		},
+		{
+			Args:  []string{"-in", "%d.%m.%Y", "-from", "strftime", "-out", "2006-01-02", "-field", "1", "-field", "3", "-strict"},
+			Input: "07.03.2021 - 08.03.2021\nnone\n",
+			Want:  "2021-03-07 - 2021-03-08\nnone\n",
+			Code:  1,
+		},
+		{
+			Args:  []string{"-in", time.Stamp, "-out", "01-02 15:04:05", "-field", "2"},
+			Input: "host1 Mar  7 14:05:09 ok\nhost1 Mar 17 14:05:09 ok\n",
+			Want:  "host1 03-07 14:05:09 ok\nhost1 03-17 14:05:09 ok\n",
+		},
+		{
+			Args:  []string{"-in", "2006-01-02T{_15}:04", "-out", "15:04", "-field", "2"},
+			Input: "at 2021-03-07T 9:05 and\nat 2021-03-07T19:05 and\n",
+			Want:  "at 09:05 and\nat 19:05 and\n",
+		},
+		{
+			Args:  []string{"-in", "2006-01-02 15:04", "-out", "3:04PM", "-in-zone", "Europe/Berlin", "-zone", "UTC"},
+			Input: "2021-03-07 13:30 lunch",
+			Want:  "12:30PM lunch",
+		},
+		{Args: []string{"-in", "2006"}, Code: 2},
+	}
+	for _, test := range testData {
+		var stdout, stderr bytes.Buffer
+		code := run(test.Args, strings.NewReader(test.Input), &stdout, &stderr)
+		if code != test.Code || stdout.String() != test.Want {
+			t.Errorf("run %q\nwant=%q exit %d\ngot= %q exit %d\n%s", test.Args, test.Want, test.Code, stdout.String(), code, stderr.String())
+		}
+	}
+}
+
+// TestChunks checks lines split across reads and longer than a chunk.
+func TestChunks(t *testing.T) {
+	long := strings.Repeat("x", 200<<10)
+	input := "2021-03-07T14:05:09Z a\n2021-03-07T14:05:10Z " + long + "\n" + long + "\n2021-03-07T14:05:11Z"
+	want := "Mar  7 14:05:09 a\nMar  7 14:05:10 " + long + "\n" + long + "\nMar  7 14:05:11"
+	args := []string{"-in", time3339, "-out", "Jan _2 15:04:05"}
+	for _, r := range []io.Reader{strings.NewReader(input), iotest.OneByteReader(strings.NewReader(input)), iotest.HalfReader(strings.NewReader(input))} {
+		var stdout, stderr bytes.Buffer
+		if code := run(args, r, &stdout, &stderr); code != 0 || stdout.String() != want {
+			t.Errorf("run %T: exit %d, output of %d bytes, want %d\n%s", r, code, stdout.Len(), len(want), stderr.String())
+		}
+	}
+}
+
+const time3339 = "2006-01-02T15:04:05Z07:00"
+
+func BenchmarkRewrite(b *testing.B) {
+	line := "2021-03-07T14:05:09.123Z host1 app[1234]: request served in 12ms status=200 path=/api/v1/items\n"
+	input := strings.Repeat(line, 10000)
+	args := []string{"-in", "2006-01-02T15:04:05Z07:00", "-out", "Jan _2 15:04:05.000"}
+	b.SetBytes(int64(len(input)))
+	for i := 0; i < b.N; i++ {
+		run(args, strings.NewReader(input), io.Discard, io.Discard)
+	}
+}
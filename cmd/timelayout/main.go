@@ -0,0 +1,346 @@
+// Command timelayout converts, explains, lints and renders time layouts, and
+// checks JSON documents against layouts given in their schemas.
+//
+// Usage:
+//
+//	timelayout convert --from DIALECT --to DIALECT PATTERN
+//	timelayout explain [--from DIALECT] [--json] LAYOUT
+//	timelayout lint [--from DIALECT] LAYOUT
+//	timelayout render [--from DIALECT] [--time RFC3339] [--zone NAME] [--locale TAG] LAYOUT
+//	timelayout lookup [--from DIALECT] NAME|LAYOUT
+//	timelayout schema [--from DIALECT] LAYOUT
+//	timelayout validate SCHEMA DOCUMENT
+//
+// Schema prints the JSON Schema of fields formatted with the layout, and
+// validate checks the fields of the JSON file DOCUMENT against the layouts
+// given by x-go-layout or x-strftime in the JSON Schema or OpenAPI file
+// SCHEMA, see timeformat.ValidateJSON.
+//
+// The exit status is 1 when a conversion is lossy, lint finds problems,
+// lookup finds nothing or validate finds fields that do not fit, and 2 for
+// usage errors.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"timeformattest/timeformat"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// errUsage is returned for bad arguments after the usage has been printed.
+var errUsage = errors.New("usage")
+
+// errFindings is returned when a command ran but reported diagnostics.
+var errFindings = errors.New("findings")
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	var err error
+	switch args[0] {
+	case "convert":
+		err = convert(args[1:], stdout, stderr)
+	case "explain":
+		err = explain(args[1:], stdout, stderr)
+	case "lint":
+		err = lint(args[1:], stdout, stderr)
+	case "render":
+		err = render(args[1:], stdout, stderr)
+	case "lookup":
+		err = lookup(args[1:], stdout, stderr)
+	case "schema":
+		err = schema(args[1:], stdout, stderr)
+	case "validate":
+		err = validate(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		usage(stdout)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "timelayout: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errFindings):
+		return 1
+	case errors.Is(err, errUsage):
+		return 2
+	}
+	fmt.Fprintln(stderr, "timelayout:", err)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, `usage:
+  timelayout convert --from DIALECT --to DIALECT PATTERN
+  timelayout explain [--from DIALECT] [--json] LAYOUT
+  timelayout lint [--from DIALECT] LAYOUT
+  timelayout render [--from DIALECT] [--time RFC3339] [--zone NAME] [--locale TAG] LAYOUT
+  timelayout lookup [--from DIALECT] NAME|LAYOUT
+  timelayout schema [--from DIALECT] LAYOUT
+  timelayout validate SCHEMA DOCUMENT
+
+dialects: %s
+`, strings.Join(timeformat.Dialects(), ", "))
+}
+
+// command parses the flags of a subcommand that takes one pattern argument.
+func command(name string, args []string, stderr io.Writer, define func(*flag.FlagSet)) (*flag.FlagSet, string, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	define(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, "", errUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintf(stderr, "timelayout %s: want exactly one pattern, got %d arguments\n", name, fs.NArg())
+		return nil, "", errUsage
+	}
+	return fs, fs.Arg(0), nil
+}
+
+// dialect looks up a dialect given on the command line.
+func dialect(name string) (*timeformat.Dialect, error) {
+	if d := timeformat.LookupDialect(name); d != nil {
+		return d, nil
+	}
+	return nil, fmt.Errorf("unknown dialect %q, want one of %s", name, strings.Join(timeformat.Dialects(), ", "))
+}
+
+// toGo translates pattern into a Go layout, printing any diagnostics.
+func toGo(from, pattern string, stderr io.Writer) (string, bool, error) {
+	d, err := dialect(from)
+	if err != nil {
+		return "", false, err
+	}
+	layout, diags := d.From(pattern)
+	report(stderr, diags)
+	return layout, len(diags) > 0, nil
+}
+
+func report(w io.Writer, diags []timeformat.Diagnostic) {
+	for _, d := range diags {
+		fmt.Fprintln(w, d)
+	}
+}
+
+func convert(args []string, stdout, stderr io.Writer) error {
+	var from, to string
+	_, pattern, err := command("convert", args, stderr, func(fs *flag.FlagSet) {
+		fs.StringVar(&from, "from", "go", "dialect of the pattern")
+		fs.StringVar(&to, "to", "go", "dialect to convert to")
+	})
+	if err != nil {
+		return err
+	}
+	src, err := dialect(from)
+	if err != nil {
+		return err
+	}
+	dst, err := dialect(to)
+	if err != nil {
+		return err
+	}
+	out, diags := timeformat.Convert(pattern, src, dst)
+	fmt.Fprintln(stdout, out)
+	report(stderr, diags)
+	if len(diags) > 0 {
+		return errFindings
+	}
+	return nil
+}
+
+func explain(args []string, stdout, stderr io.Writer) error {
+	var from string
+	var asJSON bool
+	_, pattern, err := command("explain", args, stderr, func(fs *flag.FlagSet) {
+		fs.StringVar(&from, "from", "go", "dialect of the layout")
+		fs.BoolVar(&asJSON, "json", false, "print the explanation as JSON")
+	})
+	if err != nil {
+		return err
+	}
+	layout, lossy, err := toGo(from, pattern, stderr)
+	if err != nil {
+		return err
+	}
+	e := timeformat.Explain(layout)
+	if asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintln(stdout, e)
+	}
+	if lossy {
+		return errFindings
+	}
+	return nil
+}
+
+func lint(args []string, stdout, stderr io.Writer) error {
+	var from string
+	_, pattern, err := command("lint", args, stderr, func(fs *flag.FlagSet) {
+		fs.StringVar(&from, "from", "go", "dialect of the layout")
+	})
+	if err != nil {
+		return err
+	}
+	layout, lossy, err := toGo(from, pattern, stderr)
+	if err != nil {
+		return err
+	}
+	diags := timeformat.Lint(layout)
+	report(stdout, diags)
+	if n, ok := timeformat.LookupLayout(layout); ok && n.Const != "" {
+		fmt.Fprintf(stdout, "layout is %s, use %s\n", n.Name, n.Const)
+	}
+	if lossy || len(diags) > 0 {
+		return errFindings
+	}
+	return nil
+}
+
+func lookup(args []string, stdout, stderr io.Writer) error {
+	var from string
+	_, pattern, err := command("lookup", args, stderr, func(fs *flag.FlagSet) {
+		fs.StringVar(&from, "from", "go", "dialect of the layout")
+	})
+	if err != nil {
+		return err
+	}
+	n, ok := timeformat.LookupName(pattern)
+	if !ok {
+		layout, _, err := toGo(from, pattern, io.Discard)
+		if err != nil {
+			return err
+		}
+		n, ok = timeformat.LookupLayout(layout)
+	}
+	if !ok {
+		fmt.Fprintf(stderr, "timelayout: no named layout for %q\n", pattern)
+		return errFindings
+	}
+	fmt.Fprintf(stdout, "name:     %s\n", n.Name)
+	if n.Const != "" {
+		fmt.Fprintf(stdout, "constant: %s\n", n.Const)
+	}
+	fmt.Fprintf(stdout, "go:       %s\n", n.Layout)
+	if n.StrftimeExact {
+		fmt.Fprintf(stdout, "strftime: %s\n", n.Strftime)
+	} else {
+		fmt.Fprintf(stdout, "strftime: %s (not exact)\n", n.Strftime)
+	}
+	return nil
+}
+
+func schema(args []string, stdout, stderr io.Writer) error {
+	var from string
+	_, pattern, err := command("schema", args, stderr, func(fs *flag.FlagSet) {
+		fs.StringVar(&from, "from", "go", "dialect of the layout")
+	})
+	if err != nil {
+		return err
+	}
+	layout, lossy, err := toGo(from, pattern, stderr)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(timeformat.JSONSchema(layout)); err != nil {
+		return err
+	}
+	if lossy {
+		return errFindings
+	}
+	return nil
+}
+
+func validate(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() != 2 {
+		fmt.Fprintf(stderr, "timelayout validate: want a schema and a document, got %d arguments\n", fs.NArg())
+		return errUsage
+	}
+	schema, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	document, err := os.ReadFile(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	errs, err := timeformat.ValidateJSON(schema, document)
+	if err != nil {
+		return err
+	}
+	for _, e := range errs {
+		fmt.Fprintln(stdout, e)
+	}
+	if len(errs) > 0 {
+		return errFindings
+	}
+	return nil
+}
+
+func render(args []string, stdout, stderr io.Writer) error {
+	var from, at, zone, locale string
+	_, pattern, err := command("render", args, stderr, func(fs *flag.FlagSet) {
+		fs.StringVar(&from, "from", "go", "dialect of the layout")
+		fs.StringVar(&at, "time", "", "instant to render in RFC 3339, default now")
+		fs.StringVar(&zone, "zone", "", "IANA zone to render in, default the zone of --time or local")
+		fs.StringVar(&locale, "locale", "en", "locale for names and ordinals")
+	})
+	if err != nil {
+		return err
+	}
+	layout, lossy, err := toGo(from, pattern, stderr)
+	if err != nil {
+		return err
+	}
+	t := time.Now()
+	if at != "" {
+		if t, err = time.Parse(time.RFC3339Nano, at); err != nil {
+			return err
+		}
+	}
+	if zone != "" {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			return err
+		}
+		t = t.In(loc)
+	}
+	l := timeformat.LookupLocale(locale)
+	if l == nil {
+		return fmt.Errorf("unknown locale %q", locale)
+	}
+	fmt.Fprintln(stdout, timeformat.Compile(layout).WithLocale(l).Format(t))
+	if lossy {
+		return errFindings
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	testData := []struct {
+		Args   []string
+		Stdout string
+		Code   int
+	}{
+		{Args: []string{"convert", "--from", "strftime", "--to", "go", "%F %T"}, Stdout: "2006-01-02 15:04:05\n"},
+		{Args: []string{"convert", "--from", "go", "--to", "strftime", "2006-01-02T15:04:05Z07:00"}, Stdout: "%Y-%m-%dT%H:%M:%S%:z\n", Code: 1},
+		{Args: []string{"convert", "--to", "go", "{isoweek}"}, Stdout: "{isoweek}\n", Code: 1},
+		{Args: []string{"convert", "--from", "cobol", "--to", "go", "x"}, Code: 2},
+		{Args: []string{"render", "--time", "2021-03-07T14:05:09Z", "--zone", "Asia/Tokyo", "Jan {2nd} 15:04 MST"}, Stdout: "Mar 7th 23:05 JST\n"},
+		{Args: []string{"render", "--from", "strftime", "--time", "2021-03-07T14:05:09Z", "%d.%m.%Y %k"}, Stdout: "07.03.2021 14\n"},
+		{Args: []string{"lint", "2006-01-02 15:04:05Z07:00"}},
+		{Args: []string{"lint", "2006-01-02 03:04"}, Stdout: "offset 11: \"03\": 12-hour clock without AM/PM\n", Code: 1},
+		{Args: []string{"lint", "2006-01-02"}, Stdout: "layout is DateOnly, use time.DateOnly\n"},
+		{Args: []string{"lookup", "--from", "strftime", "%F %T"}, Stdout: "name:     DateTime\nconstant: time.DateTime\ngo:       2006-01-02 15:04:05\nstrftime: %Y-%m-%d %H:%M:%S\n"},
+		{Args: []string{"lookup", "2006 01"}, Code: 1},
+		{Args: []string{"schema", "--from", "strftime", "%F"}, Stdout: `{
+  "type": "string",
+  "format": "date",
+  "pattern": "^(?:[0-9]{4})-(?:0[1-9]|1[0-2])-(?:0[1-9]|[12][0-9]|3[01])$",
+  "example": "2021-03-07",
+  "x-go-layout": "2006-01-02",
+  "x-strftime": "%Y-%m-%d"
+}
+`},
+		{Args: []string{"schema", "--from", "strftime", "%Ez"}, Code: 1},
+		{Args: []string{"validate", "schema.json"}, Code: 2},
+		{Args: []string{"explain"}, Code: 2},
+		{Args: []string{"frobnicate"}, Code: 2},
+	}
+	for _, test := range testData {
+		var stdout, stderr bytes.Buffer
+		code := run(test.Args, &stdout, &stderr)
+		if code != test.Code || test.Stdout != "" && stdout.String() != test.Stdout {
+			t.Errorf("run %q\nwant=%q exit %d\ngot= %q exit %d\n%s", test.Args, test.Stdout, test.Code, stdout.String(), code, stderr.String())
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "schema.json")
+	document := filepath.Join(dir, "document.json")
+	if err := os.WriteFile(schema, []byte(`{"items": {"x-go-layout": "2006-01-02"}}`), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	testData := []struct {
+		Document string
+		Stdout   string
+		Code     int
+	}{
+		{Document: `["2021-03-07"]`},
+		{Document: `["2021-03-07", "2021-3-7"]`, Stdout: "/1: \"2021-3-7\" does not match layout \"2006-01-02\"\n", Code: 1},
+		{Document: `[`, Code: 2},
+	}
+	for _, test := range testData {
+		if err := os.WriteFile(document, []byte(test.Document), 0o666); err != nil {
+			t.Fatal(err)
+		}
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"validate", schema, document}, &stdout, &stderr)
+		if code != test.Code || stdout.String() != test.Stdout {
+			t.Errorf("validate %s\nwant=%q exit %d\ngot= %q exit %d\n%s", test.Document, test.Stdout, test.Code, stdout.String(), code, stderr.String())
+		}
+	}
+}
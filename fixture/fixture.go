@@ -0,0 +1,149 @@
+// Package fixture loads the format and parse test cases kept as JSON or
+// JSONL files, so that other projects can run the same cases.
+//
+// A JSONL file starts with a header line and has one case per line:
+//
+//	{"version": 1}
+//	{"time": "2021-01-01T00:00:00Z", "layout": "2006", "strftime": "%Y", "output": "2021"}
+//
+// A JSON file is a single object with the version and a list of cases.
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is the fixture file version this package reads.
+const Version = 1
+
+// Case is one test case. A case with a time is a format case: the instant
+// formatted with the Go layout, and with the strftime format if there is
+// one, gives the output. A case with a parse result is a parse case: the
+// output parsed with the Go layout gives that instant. A case with an error
+// is a parse case whose output does not parse. Cases with a Python format
+// were recorded with Python's datetime and hold for the layout in the
+// Python mode of timeparse.
+type Case struct {
+	Group    string `json:"group,omitempty"`    // heading the case is listed under
+	Time     string `json:"time,omitempty"`     // instant in RFC 3339 with nanoseconds
+	Zone     string `json:"zone,omitempty"`     // IANA zone of Time and Parse, default the offset given
+	Layout   string `json:"layout"`             // Go layout
+	Strftime string `json:"strftime,omitempty"` // equivalent strftime format, if any
+	Python   string `json:"python,omitempty"`   // Python strftime and strptime format the case was recorded with
+	Output   string `json:"output"`             // formatted text, or the text to parse
+	Parse    string `json:"parse,omitempty"`    // instant the output parses to, in RFC 3339
+	Error    string `json:"error,omitempty"`    // message of the recording engine if the output does not parse
+	Note     string `json:"note,omitempty"`     // remarks, such as why there is no strftime format
+}
+
+// Instant returns the time of a format case.
+func (c Case) Instant() (time.Time, error) {
+	return c.instant(c.Time)
+}
+
+// Parsed returns the expected parse result of a parse case.
+func (c Case) Parsed() (time.Time, error) {
+	return c.instant(c.Parse)
+}
+
+func (c Case) instant(s string) (time.Time, error) {
+	t, err := parseRFC3339(s)
+	if err != nil || c.Zone == "" {
+		return t, err
+	}
+	loc, err := time.LoadLocation(c.Zone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// parseRFC3339 is time.Parse with RFC 3339, extended to the years outside
+// 0 through 9999 that time.Format writes with more digits or a sign.
+func parseRFC3339(s string) (time.Time, error) {
+	i := strings.IndexByte(s[min(len(s), 1):], '-') + 1
+	if i == 4 || i == 0 {
+		return time.Parse(time.RFC3339Nano, s)
+	}
+	year, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("bad year in %q", s)
+	}
+	t, err := time.Parse(time.RFC3339Nano, "2000"+s[i:])
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()), nil
+}
+
+// header is the first line of a JSONL file, or the whole of a JSON file.
+type header struct {
+	Version int     `json:"version"`
+	Cases   []*Case `json:"cases,omitempty"`
+}
+
+// Load reads the cases of a JSON or JSONL fixture file.
+func Load(r io.Reader) ([]Case, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var h header
+	if err := dec.Decode(&h); err != nil {
+		return nil, fmt.Errorf("fixture header: %w", err)
+	}
+	if h.Version != Version {
+		return nil, fmt.Errorf("fixture version %d, want %d", h.Version, Version)
+	}
+	var cases []Case
+	for _, c := range h.Cases {
+		cases = append(cases, *c)
+	}
+	for n := 1; ; n++ {
+		var c Case
+		if err := dec.Decode(&c); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("fixture case %d: %w", n, err)
+		}
+		if h.Cases != nil {
+			return nil, errors.New("fixture has cases after the JSON document")
+		}
+		cases = append(cases, c)
+	}
+	return cases, nil
+}
+
+// LoadFile reads the cases of the fixture file at path.
+func LoadFile(path string) ([]Case, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cases, err := Load(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cases, nil
+}
+
+// Write writes cases as a JSONL fixture file.
+func Write(w io.Writer, cases []Case) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(header{Version: Version}); err != nil {
+		return err
+	}
+	for _, c := range cases {
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
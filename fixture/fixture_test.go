@@ -0,0 +1,91 @@
+package fixture
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	want := []Case{
+		{Time: "2021-01-01T00:00:00Z", Layout: "2006", Strftime: "%Y", Output: "2021"},
+		{Layout: "2006 002", Output: "2021 145", Parse: "2021-05-25T00:00:00Z", Note: "day of the year"},
+	}
+	testData := []struct {
+		Name  string
+		Input string
+		Err   string
+	}{
+		{Name: "jsonl", Input: `{"version": 1}
+{"time": "2021-01-01T00:00:00Z", "layout": "2006", "strftime": "%Y", "output": "2021"}
+{"layout": "2006 002", "output": "2021 145", "parse": "2021-05-25T00:00:00Z", "note": "day of the year"}
+`},
+		{Name: "json", Input: `{"version": 1, "cases": [
+	{"time": "2021-01-01T00:00:00Z", "layout": "2006", "strftime": "%Y", "output": "2021"},
+	{"layout": "2006 002", "output": "2021 145", "parse": "2021-05-25T00:00:00Z", "note": "day of the year"}
+]}`},
+		{Name: "version", Input: `{"version": 2}`, Err: "fixture version 2, want 1"},
+		{Name: "unknown field", Input: "{\"version\": 1}\n{\"layuot\": \"2006\"}", Err: "fixture case 1: json: unknown field \"layuot\""},
+		{Name: "trailing", Input: `{"version": 1, "cases": []} {"layout": "2006"}`, Err: "fixture has cases after the JSON document"},
+	}
+	for _, test := range testData {
+		got, err := Load(strings.NewReader(test.Input))
+		if test.Err != "" {
+			if err == nil || err.Error() != test.Err {
+				t.Errorf("Load %s want error %q, got %v", test.Name, test.Err, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("Load %s\nwant=%+v\ngot= %+v %v", test.Name, want, got, err)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	cases := []Case{{Group: "zones", Time: "2021-01-01T01:01:01+08:00", Zone: "Asia/Shanghai", Layout: "MST", Strftime: "%Z", Output: "CST"}}
+	var buf bytes.Buffer
+	if err := Write(&buf, cases); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":1}
+{"group":"zones","time":"2021-01-01T01:01:01+08:00","zone":"Asia/Shanghai","layout":"MST","strftime":"%Z","output":"CST"}
+`
+	if buf.String() != want {
+		t.Errorf("Write\nwant=%q\ngot= %q", want, buf.String())
+	}
+	got, err := Load(&buf)
+	if err != nil || !reflect.DeepEqual(got, cases) {
+		t.Errorf("Load after Write got %+v %v", got, err)
+	}
+}
+
+func TestInstant(t *testing.T) {
+	testData := []struct {
+		Time string
+		Zone string
+		Want time.Time
+	}{
+		{Time: "2021-02-20T23:22:21.000123456+08:00", Zone: "Asia/Shanghai", Want: time.Date(2021, 2, 20, 23, 22, 21, 123456, location("Asia/Shanghai"))},
+		{Time: "2021-02-20T23:22:21Z", Want: time.Date(2021, 2, 20, 23, 22, 21, 0, time.UTC)},
+		{Time: "2021-02-20T23:22:21-05:30", Want: time.Date(2021, 2, 20, 23, 22, 21, 0, time.FixedZone("", -5*3600-30*60))},
+		{Time: "10000-01-01T00:00:00Z", Want: time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Time: "-0001-12-31T00:00:00Z", Want: time.Date(-1, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range testData {
+		got, err := Case{Time: test.Time, Zone: test.Zone}.Instant()
+		if err != nil || !got.Equal(test.Want) || got.Location().String() != test.Want.Location().String() {
+			t.Errorf("Instant %s %s\nwant=%v\ngot= %v %v", test.Time, test.Zone, test.Want, got, err)
+		}
+	}
+	if _, err := (Case{Time: "2021-02-30T00:00:00Z"}).Instant(); err == nil {
+		t.Error("Instant of February 30 did not fail")
+	}
+}
+
+func location(zone string) *time.Location {
+	loc, _ := time.LoadLocation(zone)
+	return loc
+}
@@ -0,0 +1,10 @@
+// Package glibc calls strftime and strptime of the C library, as the
+// reference the strftime dialect and timefmt-go are checked against.
+//
+// The functions are built only with the glibc tag, on Linux with cgo:
+//
+//	go test -tags glibc ./timeformat
+//
+// The process runs with TZ=UTC and the C locale for LC_TIME, so output
+// does not depend on the host.
+package glibc
@@ -0,0 +1,111 @@
+//go:build glibc && cgo && linux
+
+package glibc
+
+/*
+#define _GNU_SOURCE
+#include <locale.h>
+#include <stdlib.h>
+#include <string.h>
+#include <time.h>
+
+static void setup(void) {
+	setenv("TZ", "UTC", 1);
+	tzset();
+	setlocale(LC_TIME, "C");
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+func init() {
+	C.setup()
+}
+
+// year matches directives glibc does not pad to four digits.
+var year = regexp.MustCompile(`%[YGCFc]`)
+
+// Differs explains a known difference of glibc from timefmt-go and the
+// strftime dialect for format and t, or returns "" if there is none.
+func Differs(format string, t time.Time) string {
+	name, offset := t.Zone()
+	switch {
+	case strings.Contains(format, "%f") || strings.Contains(format, "%v") || strings.Contains(format, "%+"):
+		return "not a glibc directive"
+	case t.Year() < 1000 && year.MatchString(format):
+		return "glibc does not pad years below 1000"
+	case strings.Contains(format, "%s") && offset != 0:
+		return "glibc reads the clock as TZ, which is UTC"
+	case strings.Contains(format, "Z") && name == "":
+		return "Go writes the offset for zones without a name"
+	}
+	return ""
+}
+
+// maxOutput bounds the output of Strftime.
+const maxOutput = 4096
+
+// Strftime formats t with format. Zone directives use the offset and
+// abbreviation of t; %s treats the clock of t as UTC, as glibc does with
+// TZ=UTC.
+func Strftime(t time.Time, format string) (string, error) {
+	if format == "" {
+		return "", nil
+	}
+	name, offset := t.Zone()
+	zone := C.CString(name)
+	defer C.free(unsafe.Pointer(zone))
+	tm := C.struct_tm{
+		tm_year:   C.int(t.Year() - 1900),
+		tm_mon:    C.int(t.Month() - 1),
+		tm_mday:   C.int(t.Day()),
+		tm_hour:   C.int(t.Hour()),
+		tm_min:    C.int(t.Minute()),
+		tm_sec:    C.int(t.Second()),
+		tm_wday:   C.int(t.Weekday()),
+		tm_yday:   C.int(t.YearDay() - 1),
+		tm_gmtoff: C.long(offset),
+		tm_zone:   zone,
+	}
+	f := C.CString(format)
+	defer C.free(unsafe.Pointer(f))
+	buf := (*C.char)(C.malloc(maxOutput))
+	defer C.free(unsafe.Pointer(buf))
+	n := C.strftime(buf, maxOutput, f, &tm)
+	if n == 0 {
+		// either empty output or too long, glibc does not tell which
+		return "", nil
+	}
+	return C.GoStringN(buf, C.int(n)), nil
+}
+
+// Strptime parses s with format. Fields the format lacks default to
+// 1900-01-01 00:00:00, the year glibc counts from; the result is in UTC
+// unless the format has %z.
+func Strptime(s, format string) (time.Time, error) {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	f := C.CString(format)
+	defer C.free(unsafe.Pointer(f))
+	tm := C.struct_tm{tm_mday: 1}
+	end := C.strptime(cs, f, &tm)
+	if end == nil {
+		return time.Time{}, fmt.Errorf("strptime %q with %q failed", s, format)
+	}
+	if rest := C.GoString(end); rest != "" {
+		return time.Time{}, fmt.Errorf("strptime %q with %q: extra text %q", s, format, rest)
+	}
+	loc := time.UTC
+	if tm.tm_gmtoff != 0 {
+		loc = time.FixedZone("", int(tm.tm_gmtoff))
+	}
+	return time.Date(int(tm.tm_year)+1900, time.Month(tm.tm_mon+1), int(tm.tm_mday),
+		int(tm.tm_hour), int(tm.tm_min), int(tm.tm_sec), 0, loc), nil
+}
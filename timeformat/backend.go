@@ -0,0 +1,105 @@
+package timeformat
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+)
+
+// ErrUnsupported is returned by a Backend for layouts it cannot express.
+var ErrUnsupported = errors.New("layout not supported by backend")
+
+// Backend is a formatting and parsing engine. Layouts are Go layouts with
+// extended tokens; a backend for another format language translates them
+// and returns ErrUnsupported when the translation is not exact.
+type Backend interface {
+	Name() string
+	Format(t time.Time, layout string) (string, error)
+	Parse(layout, s string) (time.Time, error)
+}
+
+var backends = map[string]Backend{}
+
+// RegisterBackend adds b to the backends known to LookupBackend. It panics
+// if a backend with the same name is already registered.
+func RegisterBackend(b Backend) {
+	if _, dup := backends[b.Name()]; dup {
+		panic("timeformat: backend " + b.Name() + " registered twice")
+	}
+	backends[b.Name()] = b
+}
+
+func init() {
+	RegisterBackend(stdlibBackend{})
+	RegisterBackend(timefmtBackend{})
+}
+
+// LookupBackend returns the backend with the given name, or nil.
+func LookupBackend(name string) Backend {
+	return backends[name]
+}
+
+// Backends returns the registered backends sorted by name. The go and
+// timefmt backends are always registered; importing timeparse adds the
+// compiled engine of this module.
+func Backends() []Backend {
+	list := make([]Backend, 0, len(backends))
+	for _, b := range backends {
+		list = append(list, b)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list
+}
+
+// stdlibBackend is the time package.
+type stdlibBackend struct{}
+
+func (stdlibBackend) Name() string { return "go" }
+
+func (stdlibBackend) Format(t time.Time, layout string) (string, error) {
+	if extendedTokens(layout) {
+		return "", ErrUnsupported
+	}
+	return t.Format(layout), nil
+}
+
+func (stdlibBackend) Parse(layout, s string) (time.Time, error) {
+	if extendedTokens(layout) {
+		return time.Time{}, ErrUnsupported
+	}
+	return time.Parse(layout, s)
+}
+
+// extendedTokens reports whether layout has tokens the time package lacks.
+func extendedTokens(layout string) bool {
+	for _, token := range Compile(layout).tokens {
+		if token.Kind > FracSecond9 {
+			return true
+		}
+	}
+	return false
+}
+
+// timefmtBackend is github.com/itchyny/timefmt-go, given the strftime
+// translation of each layout.
+type timefmtBackend struct{}
+
+func (timefmtBackend) Name() string { return "timefmt" }
+
+func (timefmtBackend) Format(t time.Time, layout string) (string, error) {
+	format, diags := ToStrftime(layout)
+	if len(diags) > 0 {
+		return "", ErrUnsupported
+	}
+	return timefmt.Format(t, format), nil
+}
+
+func (timefmtBackend) Parse(layout, s string) (time.Time, error) {
+	format, diags := ToStrftime(layout)
+	if len(diags) > 0 {
+		return time.Time{}, ErrUnsupported
+	}
+	return timefmt.Parse(s, format)
+}
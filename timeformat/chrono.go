@@ -0,0 +1,405 @@
+package timeformat
+
+import "strings"
+
+// chronoComposite holds the specifiers of Rust's chrono that stand for
+// several others. Unlike strftime, %+ is RFC 3339.
+var chronoComposite = map[byte]string{
+	'c': "%a %b %e %H:%M:%S %Y",
+	'+': "%Y-%m-%dT%H:%M:%S%.f%:z",
+	'F': "%Y-%m-%d",
+	'D': "%m/%d/%y",
+	'x': "%m/%d/%y",
+	'v': "%e-%b-%Y",
+	'T': "%H:%M:%S",
+	'X': "%H:%M:%S",
+	'r': "%I:%M:%S %p",
+	'R': "%H:%M",
+}
+
+// cppConversions are the conversion specifiers of C++ std::format for
+// time points, cppE and cppO those that take the E and O modifiers.
+const (
+	cppConversions = "aAbBcCdDeFgGhHIjmMnprRStTuUVwWxXyYzZ%"
+	cppE           = "cCxXyYz"
+	cppO           = "deHImMSuUVwWyz"
+)
+
+const cppSeconds = "C++ adds fraction digits to %S for time points finer than seconds"
+
+// FromChrono translates a format of Rust's chrono crate into a layout. The
+// specifiers are those of strftime with chrono's extensions: %f is nine
+// digits of nanoseconds, %.3f, %.6f and %.9f are fractions with a dot,
+// %3f, %6f and %9f are fractions without one, and %:::z is an offset in
+// hours. Specifiers with no exact counterpart are reported and translated
+// to the closest token, or kept as literal text.
+func FromChrono(format string) (string, []Diagnostic) {
+	var b builder
+	fromChrono(&b, format, 0, -1, false)
+	return b.layout()
+}
+
+// FromCpp translates a C++ std::format string for a time point, such as
+// "{:%F %T}", into a layout. Text outside the replacement fields is copied,
+// a field without a chrono spec is formatted as "%F %T", and a string with
+// no braces at all is read as a chrono spec on its own. Since the fraction
+// digits of %S and %T depend on the precision of the time point, they are
+// reported.
+func FromCpp(format string) (string, []Diagnostic) {
+	var b builder
+	if !strings.ContainsAny(format, "{}") {
+		fromChrono(&b, format, 0, -1, true)
+		return b.layout()
+	}
+	for i := 0; i < len(format); i++ {
+		switch {
+		case strings.HasPrefix(format[i:], "{{"), strings.HasPrefix(format[i:], "}}"):
+			b.literal(i, format[i:i+1])
+			i++
+		case format[i] == '}':
+			b.literal(i, "}")
+			b.lossy(i, "}", "unmatched '}'")
+		case format[i] == '{':
+			j := strings.IndexByte(format[i:], '}')
+			if j < 0 {
+				b.literal(i, format[i:])
+				b.lossy(i, format[i:], "unterminated replacement field")
+				return b.layout()
+			}
+			field := format[i+1 : i+j]
+			colon := strings.IndexByte(field, ':')
+			if colon < 0 {
+				fromChrono(&b, "%F %T", 0, i, true)
+			} else {
+				spec := i + 1 + colon + 1
+				switch k := strings.IndexByte(format[spec:i+j], '%'); {
+				case k < 0:
+					b.lossy(spec, format[spec:i+j], "fill, width and precision are ignored")
+					fromChrono(&b, "%F %T", 0, i, true)
+				case k > 0:
+					b.lossy(spec, format[spec:spec+k], "fill, width and precision are ignored")
+					fallthrough
+				default:
+					fromChrono(&b, format[:i+j], spec+k, -1, true)
+				}
+			}
+			i += j
+		default:
+			b.literal(i, format[i:i+1])
+		}
+	}
+	return b.layout()
+}
+
+// fromChrono adds the tokens of format from index i on to b, for chrono or
+// for C++. Composites are expanded with all offsets set to at.
+func fromChrono(b *builder, format string, i, at int, cpp bool) {
+	for ; i < len(format); i++ {
+		offset := at
+		if at < 0 {
+			offset = i
+		}
+		if format[i] != '%' {
+			b.literal(offset, format[i:i+1])
+			continue
+		}
+		j := i + 1
+		if j < len(format) && (cpp && strings.IndexByte("EO", format[j]) >= 0 ||
+			!cpp && strings.IndexByte("-_0#.:369", format[j]) >= 0) {
+			j++
+		}
+		for !cpp && j < len(format) && strings.IndexByte(".:369", format[j]) >= 0 {
+			j++
+		}
+		if j >= len(format) {
+			b.literal(offset, format[i:])
+			b.lossy(offset, format[i:], "incomplete specifier")
+			return
+		}
+		directive, modifier := format[i:j+1], format[i+1:j]
+		c := format[j]
+		i = j
+		unknown := func() {
+			b.literal(offset, directive)
+			b.lossy(offset, directive, "unknown specifier")
+		}
+		switch {
+		case c == '%' && modifier == "":
+			b.literal(offset, "%")
+			continue
+		case c == 't' && modifier == "":
+			b.literal(offset, "\t")
+			continue
+		case c == 'n' && modifier == "":
+			b.literal(offset, "\n")
+			continue
+		case c == 'f' && !cpp:
+			chronoFraction(b, offset, directive, modifier)
+			continue
+		case c == 'z':
+			chronoOffset(b, offset, directive, modifier, cpp)
+			continue
+		case cpp && strings.IndexByte(cppConversions, c) < 0,
+			modifier == "E" && strings.IndexByte(cppE, c) < 0,
+			modifier == "O" && strings.IndexByte(cppO, c) < 0:
+			unknown()
+			continue
+		case !cpp && modifier != "" && (len(modifier) > 1 || strings.IndexByte("-_0", modifier[0]) < 0):
+			unknown()
+			continue
+		}
+		composites := chronoComposite
+		if cpp {
+			composites = strftimeComposite
+		}
+		if composite, ok := composites[c]; ok {
+			switch {
+			case modifier != "" && !cpp:
+				b.lossy(offset, directive, "padding on a composite specifier is ignored")
+			case c == 'T' && cpp:
+				b.lossy(offset, directive, cppSeconds)
+			}
+			fromChrono(b, composite, 0, offset, cpp)
+			continue
+		}
+		conv, ok := strftime[c]
+		if !ok {
+			unknown()
+			continue
+		}
+		token := conv.token
+		if !cpp && modifier != "" {
+			if padded, ok := strftimePadded[c]; ok {
+				if p := padded[strings.IndexByte("-_0", modifier[0])]; p.Text != "" {
+					token = p
+				} else {
+					b.lossy(offset, directive, "no layout token with this padding")
+				}
+			} else if conv.width > 1 && modifier != "0" {
+				b.lossy(offset, directive, "no layout token with this padding")
+			}
+		}
+		switch {
+		case c == 'Z' && !cpp:
+			b.lossy(offset, directive, "chrono writes the offset for zones without a name and skips names when parsing")
+		case c == 'S' && cpp && at < 0:
+			// composites other than %T have no fraction
+			b.lossy(offset, directive, cppSeconds)
+		}
+		b.token(offset, token.Kind, token.Text)
+	}
+}
+
+// chronoFraction adds the token of a chrono fraction specifier: %f, %.f,
+// or %3f, %6f and %9f with or without a dot.
+func chronoFraction(b *builder, offset int, directive, modifier string) {
+	digits := strings.TrimPrefix(modifier, ".")
+	switch {
+	case modifier == "":
+		b.token(offset, Nanoseconds, "{frac9}")
+	case modifier == ".":
+		b.token(offset, FracSecond9, ".999999999")
+		b.lossy(offset, directive, "chrono writes 3, 6 or 9 digits, layouts drop all trailing zeros")
+	case len(digits) != 1 || strings.IndexByte("369", digits[0]) < 0:
+		b.literal(offset, directive)
+		b.lossy(offset, directive, "unknown specifier")
+	case digits != modifier:
+		b.token(offset, FracSecond0, "."+strings.Repeat("0", int(digits[0]-'0')))
+	default:
+		b.token(offset, map[byte]Kind{'3': Milliseconds, '6': Microseconds, '9': Nanoseconds}[digits[0]],
+			map[byte]string{'3': "{frac3}", '6': "{frac6}", '9': "{frac9}"}[digits[0]])
+	}
+}
+
+// chronoOffset adds the token of an offset specifier: %z, %:z, %::z,
+// %:::z and %#z in chrono, %z, %Ez and %Oz in C++.
+func chronoOffset(b *builder, offset int, directive, modifier string, cpp bool) {
+	switch {
+	case modifier == "":
+		b.token(offset, NumTZ, "-0700")
+	case cpp || modifier == ":":
+		b.token(offset, NumColonTZ, "-07:00")
+	case modifier == "::":
+		b.token(offset, NumColonSecondsTZ, "-07:00:00")
+	case modifier == ":::":
+		b.token(offset, NumShortTZ, "-07")
+	case modifier == "#":
+		b.token(offset, NumTZ, "-0700")
+		b.lossy(offset, directive, "%#z is for parsing, where minutes may be left out")
+	default:
+		b.literal(offset, directive)
+		b.lossy(offset, directive, "unknown specifier")
+	}
+}
+
+// ToChrono translates a layout into a format of Rust's chrono crate.
+// Tokens with no exact counterpart are reported and translated to the
+// closest specifier.
+func ToChrono(layout string) (string, []Diagnostic) {
+	var out strings.Builder
+	var diags []Diagnostic
+	lossy := func(offset int, token Token, message string) {
+		diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: message})
+	}
+	offset := 0
+	for _, token := range Tokenize(layout) {
+		switch token.Kind {
+		case Literal:
+			out.WriteString(strings.ReplaceAll(token.Text, "%", "%%"))
+		case FracSecond0:
+			n := token.Digits()
+			if token.Separator() == '.' {
+				out.WriteString("%." + chronoDigits(n) + "f")
+			} else {
+				out.WriteString(",%" + chronoDigits(n) + "f")
+			}
+			if n != 3 && n != 6 && n != 9 {
+				lossy(offset, token, "chrono only has 3, 6 or 9 fraction digits")
+			}
+		case FracSecond9:
+			if token.Separator() == '.' {
+				out.WriteString("%.f")
+				lossy(offset, token, "chrono writes 3, 6 or 9 digits, layouts drop all trailing zeros")
+			} else {
+				// %.f writes its own dot
+				out.WriteString(",%" + chronoDigits(token.Digits()) + "f")
+				lossy(offset, token, "chrono has no fraction after a comma that drops trailing zeros, keeping them")
+			}
+		case Milliseconds:
+			out.WriteString("%3f")
+		case Microseconds:
+			out.WriteString("%6f")
+		case Nanoseconds:
+			out.WriteString("%9f")
+		case NumShortTZ:
+			out.WriteString("%:::z")
+		case ISO8601ShortTZ:
+			out.WriteString("%:::z")
+			lossy(offset, token, "chrono writes +00 instead of Z for UTC")
+		case ISO8601TZ:
+			out.WriteString("%z")
+			lossy(offset, token, "chrono writes +0000 instead of Z for UTC")
+		case ISO8601ColonTZ:
+			out.WriteString("%:z")
+			lossy(offset, token, "chrono writes +00:00 instead of Z for UTC")
+		case ISO8601ColonSecondsTZ:
+			out.WriteString("%::z")
+			lossy(offset, token, "chrono writes +00:00:00 instead of Z for UTC")
+		case ISO8601SecondsTZ, NumSecondsTZ:
+			out.WriteString("%::z")
+			lossy(offset, token, "chrono has no offset with seconds and without colons, using %::z")
+		case TZ:
+			out.WriteString("%Z")
+			lossy(offset, token, "chrono writes the offset for zones without a name and skips names when parsing")
+		case OrdinalDay:
+			out.WriteString("%-d")
+			lossy(offset, token, "chrono has no ordinal day")
+		default:
+			directive, message := strftimeDirective(token)
+			out.WriteString(directive)
+			if message != "" {
+				lossy(offset, token, "no chrono specifier")
+			}
+		}
+		offset += len(token.Text)
+	}
+	return out.String(), diags
+}
+
+// chronoDigits returns the digits of the chrono fraction that holds n
+// digits: 3, 6 or 9.
+func chronoDigits(n int) string {
+	switch {
+	case n <= 3:
+		return "3"
+	case n <= 6:
+		return "6"
+	}
+	return "9"
+}
+
+// ToCpp translates a layout into a C++ std::format string with a single
+// replacement field. Text before the first token is kept outside the field,
+// since a chrono spec starts with a specifier. Tokens with no exact
+// counterpart are reported and translated to the closest specifier.
+func ToCpp(layout string) (string, []Diagnostic) {
+	var out strings.Builder
+	var diags []Diagnostic
+	lossy := func(offset int, token Token, message string) {
+		diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: message})
+	}
+	tokens := Tokenize(layout)
+	offset, open := 0, false
+	for i, token := range tokens {
+		if token.Kind != Literal && !open {
+			out.WriteString("{:")
+			open = true
+		}
+		switch token.Kind {
+		case Literal:
+			if !open {
+				out.WriteString(strings.NewReplacer("{", "{{", "}", "}}").Replace(token.Text))
+				break
+			}
+			if strings.ContainsAny(token.Text, "{}") {
+				lossy(offset, token, "a chrono spec cannot hold braces, dropped")
+			}
+			out.WriteString(strings.NewReplacer("%", "%%", "{", "", "}", "").Replace(token.Text))
+		case ZeroSecond:
+			out.WriteString("%S")
+			if i+1 < len(tokens) && tokens[i+1].Separator() == '.' &&
+				(tokens[i+1].Kind == FracSecond0 || tokens[i+1].Kind == FracSecond9) {
+				break
+			}
+			lossy(offset, token, cppSeconds)
+		case FracSecond0, FracSecond9:
+			if i > 0 && tokens[i-1].Kind == ZeroSecond && token.Separator() == '.' {
+				lossy(offset, token, "C++ writes the fraction digits of the time point's precision after %S")
+			} else {
+				lossy(offset, token, "C++ only has fractions after %S")
+			}
+		case OrdinalDay:
+			out.WriteString("%d")
+			lossy(offset, token, "C++ has no ordinal day")
+		case LowerPM:
+			out.WriteString("%p")
+			lossy(offset, token, "C++ has no lower-case AM/PM")
+		case NumColonTZ:
+			out.WriteString("%Ez")
+		case ISO8601ColonTZ:
+			out.WriteString("%Ez")
+			lossy(offset, token, "C++ writes +00:00 instead of Z for UTC")
+		case ISO8601TZ:
+			out.WriteString("%z")
+			lossy(offset, token, "C++ writes +0000 instead of Z for UTC")
+		case ISO8601ShortTZ, ISO8601SecondsTZ, ISO8601ColonSecondsTZ, NumShortTZ, NumSecondsTZ, NumColonSecondsTZ:
+			out.WriteString("%z")
+			lossy(offset, token, "C++ has no such offset form, using %z")
+		default:
+			directive, message := strftimeDirective(token)
+			switch {
+			case message != "" || directive == "%s" || directive == "%f":
+				directive = ""
+			case strings.HasPrefix(directive, "%-") || strings.HasPrefix(directive, "%_"):
+				directive = "%" + directive[2:]
+				message = "C++ has no padding modifiers"
+			case directive == "%k" || directive == "%l":
+				directive = map[string]string{"%k": "%H", "%l": "%I"}[directive]
+				message = "C++ has no padding modifiers"
+			}
+			if directive == "" {
+				lossy(offset, token, "no C++ specifier")
+				break
+			}
+			out.WriteString(directive)
+			if message != "" {
+				lossy(offset, token, message)
+			}
+		}
+		offset += len(token.Text)
+	}
+	if open {
+		out.WriteString("}")
+	}
+	return out.String(), diags
+}
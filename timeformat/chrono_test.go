@@ -0,0 +1,146 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromChrono(t *testing.T) {
+	testData := []struct {
+		Chrono string
+		Layout string
+		Lossy  bool
+	}{
+		{Chrono: "%Y-%m-%dT%H:%M:%S%.3f%:z", Layout: "2006-01-02T15:04:05.000-07:00"},
+		{Chrono: "%F %T%.6f %z", Layout: "2006-01-02 15:04:05.000000 -0700"},
+		{Chrono: "%H:%M:%S%.9f %::z %:::z", Layout: "15:04:05.000000000 -07:00:00 -07"},
+		{Chrono: "%S.%f|%3f|%6f|%9f", Layout: "05.{frac9}|{frac3}|{frac6}|{frac9}"},
+		{Chrono: "%-d %-m %e %k %l %P %_j", Layout: "2 1 _2 {_15} {_3} pm __2"},
+		{Chrono: "%c", Layout: "Mon Jan _2 15:04:05 2006"},
+		{Chrono: "%s%%", Layout: "{unix}%"},
+		{Chrono: "%+", Layout: "2006-01-02T15:04:05.999999999-07:00", Lossy: true},
+		{Chrono: "%S%.f", Layout: "05.999999999", Lossy: true},
+		{Chrono: "%Z", Layout: "MST", Lossy: true},
+		{Chrono: "%#z", Layout: "-0700", Lossy: true},
+		{Chrono: "%.4f", Layout: "%.4f", Lossy: true},
+		{Chrono: "%^B", Layout: "%^B", Lossy: true},
+		{Chrono: "%Y%", Layout: "2006%", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromChrono(test.Chrono)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromChrono %q\nwant=%q lossy=%v\ngot= %q %v", test.Chrono, test.Layout, test.Lossy, layout, diags)
+		}
+	}
+}
+
+func TestToChrono(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Chrono string
+		Lossy  bool
+	}{
+		{Layout: "2006-01-02T15:04:05.000-07:00", Chrono: "%Y-%m-%dT%H:%M:%S%.3f%:z"},
+		{Layout: "15:04:05,000000 -07:00:00 -07", Chrono: "%H:%M:%S,%6f %::z %:::z"},
+		{Layout: "{frac3}{frac6}{frac9} at 80%", Chrono: "%3f%6f%9f at 80%%"},
+		{Layout: "2 _2 3 pm", Chrono: "%-d %e %-I %P"},
+		{Layout: "15:04:05.0000", Chrono: "%H:%M:%S%.6f", Lossy: true},
+		{Layout: "15:04:05.999", Chrono: "%H:%M:%S%.f", Lossy: true},
+		{Layout: "15:04:05,999", Chrono: "%H:%M:%S,%3f", Lossy: true},
+		{Layout: "15:04:05,999999999", Chrono: "%H:%M:%S,%9f", Lossy: true},
+		// the zone layouts of timeparse/testdata/parse.jsonl
+		{Layout: "Z07:00", Chrono: "%:z", Lossy: true},
+		{Layout: "Z07:00:00", Chrono: "%::z", Lossy: true},
+		{Layout: "-070000", Chrono: "%::z", Lossy: true},
+		{Layout: "MST", Chrono: "%Z", Lossy: true},
+	}
+	for _, test := range testData {
+		format, diags := ToChrono(test.Layout)
+		if format != test.Chrono || (len(diags) > 0) != test.Lossy {
+			t.Errorf("ToChrono %q\nwant=%q lossy=%v\ngot= %q %v", test.Layout, test.Chrono, test.Lossy, format, diags)
+		}
+	}
+}
+
+// TestChronoFormat formats the example of chrono's documentation, moved
+// from a leap second to the second before.
+func TestChronoFormat(t *testing.T) {
+	ts := time.Date(2001, 7, 8, 0, 34, 59, 26490000, time.FixedZone("", 9*3600+30*60))
+	testData := []struct {
+		Chrono string
+		Output string
+	}{
+		{Chrono: "%z", Output: "+0930"},
+		{Chrono: "%:z", Output: "+09:30"},
+		{Chrono: "%::z", Output: "+09:30:00"},
+		{Chrono: "%:::z", Output: "+09"},
+		{Chrono: "%.3f", Output: ".026"},
+		{Chrono: "%.6f", Output: ".026490"},
+		{Chrono: "%.9f", Output: ".026490000"},
+		{Chrono: "%3f", Output: "026"},
+		{Chrono: "%6f", Output: "026490"},
+		{Chrono: "%9f", Output: "026490000"},
+		{Chrono: "%f", Output: "026490000"},
+		{Chrono: "%c", Output: "Sun Jul  8 00:34:59 2001"},
+		{Chrono: "%v", Output: " 8-Jul-2001"},
+		{Chrono: "%r", Output: "12:34:59 AM"},
+		{Chrono: "%k|%l|%e|%P", Output: " 0|12| 8|am"},
+	}
+	for _, test := range testData {
+		layout, diags := FromChrono(test.Chrono)
+		if got := Format(ts, layout); got != test.Output || len(diags) > 0 {
+			t.Errorf("FromChrono %q: %q formats %q %v, want %q", test.Chrono, layout, got, diags, test.Output)
+		}
+	}
+}
+
+func TestFromCpp(t *testing.T) {
+	testData := []struct {
+		Cpp    string
+		Layout string
+		Lossy  bool
+	}{
+		{Cpp: "{:%Y-%m-%d}", Layout: "2006-01-02"},
+		{Cpp: "at {0:%H:%M %Ez} {{UTC}}", Layout: "at 15:04 -07:00 {UTC}"},
+		{Cpp: "%d %b %Y %z %Oz %Z", Layout: "02 Jan 2006 -0700 -07:00 MST"},
+		{Cpp: "{:%c}", Layout: "Mon Jan _2 15:04:05 2006"},
+		{Cpp: "{:%F}T{:%R}", Layout: "2006-01-02T15:04"},
+		{Cpp: "{:%F %T}", Layout: "2006-01-02 15:04:05", Lossy: true},
+		{Cpp: "{:%S}", Layout: "05", Lossy: true},
+		{Cpp: "{}", Layout: "2006-01-02 15:04:05", Lossy: true},
+		{Cpp: "{:>12%F}", Layout: "2006-01-02", Lossy: true},
+		{Cpp: "{:%-d %k %f}", Layout: "%-d %k %f", Lossy: true},
+		{Cpp: "{:%Ed}", Layout: "%Ed", Lossy: true},
+		{Cpp: "{:%F", Layout: "{:%F", Lossy: true},
+		{Cpp: "%F}", Layout: "%F}", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromCpp(test.Cpp)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromCpp %q\nwant=%q lossy=%v\ngot= %q %v", test.Cpp, test.Layout, test.Lossy, layout, diags)
+		}
+	}
+}
+
+func TestToCpp(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Cpp    string
+		Lossy  bool
+	}{
+		{Layout: "2006-01-02", Cpp: "{:%Y-%m-%d}"},
+		{Layout: "at 15:04 -07:00 MST", Cpp: "at {:%H:%M %Ez %Z}"},
+		{Layout: "{at} Jan _2 at 80%", Cpp: "{{at}} {:%b %e at 80%%}"},
+		{Layout: "15:04:05.000000000", Cpp: "{:%H:%M:%S}", Lossy: true},
+		{Layout: "15:04:05", Cpp: "{:%H:%M:%S}", Lossy: true},
+		{Layout: "Jan 2 3pm", Cpp: "{:%b %d %I%p}", Lossy: true},
+		{Layout: "Z07:00 {unix}", Cpp: "{:%Ez }", Lossy: true},
+		{Layout: "no tokens", Cpp: "no tokens"},
+	}
+	for _, test := range testData {
+		format, diags := ToCpp(test.Layout)
+		if format != test.Cpp || (len(diags) > 0) != test.Lossy {
+			t.Errorf("ToCpp %q\nwant=%q lossy=%v\ngot= %q %v", test.Layout, test.Cpp, test.Lossy, format, diags)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package timeformat
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Diagnostic reports a construct that a translation or lint check could not
+// carry over exactly.
+type Diagnostic struct {
+	Offset  int    // byte offset in the source pattern
+	Text    string // the construct as written in the source
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return "offset " + strconv.Itoa(d.Offset) + ": " + strconv.Quote(d.Text) + ": " + d.Message
+}
+
+// Dialect translates patterns of another format language to and from Go
+// layouts with extended tokens. A translation that cannot be exact returns
+// the closest result together with diagnostics.
+type Dialect struct {
+	Name string
+	From func(pattern string) (string, []Diagnostic) // pattern to Go layout
+	To   func(layout string) (string, []Diagnostic)  // Go layout to pattern
+}
+
+var dialects = map[string]*Dialect{}
+
+// registerDialect adds d to the dialects known to LookupDialect.
+func registerDialect(d *Dialect) {
+	dialects[d.Name] = d
+}
+
+func init() {
+	registerDialect(&Dialect{Name: "go", From: fromGo, To: toGo})
+	registerDialect(&Dialect{Name: "strftime", From: FromStrftime, To: ToStrftime})
+	registerDialect(&Dialect{Name: "java", From: FromJava, To: ToJava})
+	registerDialect(&Dialect{Name: "icu", From: FromICU, To: ToICU})
+	registerDialect(&Dialect{Name: "moment", From: FromMoment, To: ToMoment})
+	registerDialect(&Dialect{Name: "date-fns", From: FromDateFns, To: ToDateFns})
+	registerDialect(&Dialect{Name: "dotnet", From: FromDotNet, To: ToDotNet})
+	registerDialect(&Dialect{Name: "postgres", From: FromPostgres, To: ToPostgres})
+	registerDialect(&Dialect{Name: "oracle", From: FromOracle, To: ToOracle})
+	registerDialect(&Dialect{Name: "mysql", From: FromMySQL, To: ToMySQL})
+	registerDialect(&Dialect{Name: "python", From: FromPython, To: ToPython})
+	registerDialect(&Dialect{Name: "chrono", From: FromChrono, To: ToChrono})
+	registerDialect(&Dialect{Name: "cpp", From: FromCpp, To: ToCpp})
+}
+
+// LookupDialect returns the dialect with the given name, or nil.
+func LookupDialect(name string) *Dialect {
+	return dialects[name]
+}
+
+// Dialects returns the names of the known dialects in sorted order.
+func Dialects() []string {
+	names := make([]string, 0, len(dialects))
+	for name := range dialects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Convert translates pattern from one dialect to another through a Go
+// layout. Diagnostics of the second step have offsets in that layout.
+func Convert(pattern string, from, to *Dialect) (string, []Diagnostic) {
+	layout, diags := from.From(pattern)
+	out, more := to.To(layout)
+	return out, append(diags, more...)
+}
+
+// fromGo is the identity translation of the go dialect.
+func fromGo(layout string) (string, []Diagnostic) {
+	return layout, nil
+}
+
+// builder collects the tokens of a translated layout along with the offset
+// in the source pattern each one came from.
+type builder struct {
+	tokens  []Token
+	offsets []int
+	diags   []Diagnostic
+}
+
+// literal adds text to be copied as is.
+func (b *builder) literal(offset int, text string) {
+	if n := len(b.tokens); n > 0 && b.tokens[n-1].Kind == Literal {
+		b.tokens[n-1].Text += text
+		return
+	}
+	b.tokens = append(b.tokens, Token{Kind: Literal, Text: text})
+	b.offsets = append(b.offsets, offset)
+}
+
+// token adds a layout token. Extended tokens are given by their spelling.
+func (b *builder) token(offset int, kind Kind, text string) {
+	b.tokens = append(b.tokens, Token{Kind: kind, Text: text})
+	b.offsets = append(b.offsets, offset)
+}
+
+// lossy records that the source construct text has no exact translation.
+func (b *builder) lossy(offset int, text, message string) {
+	b.diags = append(b.diags, Diagnostic{Offset: offset, Text: text, Message: message})
+}
+
+// fraction adds a FracSecond token of kind with n digits if the last token
+// is a literal ending in '.' or ',', which becomes the separator. It
+// returns the separator, or 0 if there is none.
+func (b *builder) fraction(offset int, kind Kind, n int) byte {
+	last := len(b.tokens) - 1
+	if last < 0 || b.tokens[last].Kind != Literal {
+		return 0
+	}
+	lit := b.tokens[last].Text
+	sep := lit[len(lit)-1]
+	if sep != '.' && sep != ',' {
+		return 0
+	}
+	if len(lit) == 1 {
+		b.tokens, b.offsets = b.tokens[:last], b.offsets[:last]
+	} else {
+		b.tokens[last].Text = lit[:len(lit)-1]
+	}
+	digit := "0"
+	if kind == FracSecond9 {
+		digit = "9"
+	}
+	b.token(offset-1, kind, string(sep)+strings.Repeat(digit, n))
+	return sep
+}
+
+// layout joins the tokens. Go layouts cannot escape literal text, so text
+// that would read back as something else is reported.
+func (b *builder) layout() (string, []Diagnostic) {
+	var layout []byte
+	for _, token := range b.tokens {
+		layout = append(layout, token.Text...)
+	}
+	got := Tokenize(string(layout))
+	for i, token := range b.tokens {
+		if i >= len(got) || got[i] != token {
+			b.lossy(b.offsets[i], token.Text, "reads back as a different token, Go layouts cannot escape text")
+			break
+		}
+	}
+	return string(layout), b.diags
+}
+
+// toGo is the translation to the go dialect, which reports the extended
+// tokens: the time package does not know them.
+func toGo(layout string) (string, []Diagnostic) {
+	var diags []Diagnostic
+	offset := 0
+	for _, token := range Tokenize(layout) {
+		if token.Kind >= OrdinalDay {
+			diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: "extended token, not a layout of the time package"})
+		}
+		offset += len(token.Text)
+	}
+	return layout, diags
+}
@@ -0,0 +1,31 @@
+package timeformat
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDialectsEmpty checks that every dialect translates the empty pattern
+// to itself.
+func TestDialectsEmpty(t *testing.T) {
+	for _, name := range Dialects() {
+		d := LookupDialect(name)
+		if got, diags := d.From(""); got != "" || len(diags) > 0 {
+			t.Errorf("%s From %q\nwant=%q\ngot= %q %v", name, "", "", got, diags)
+		}
+		if got, diags := d.To(""); got != "" || len(diags) > 0 {
+			t.Errorf("%s To %q\nwant=%q\ngot= %q %v", name, "", "", got, diags)
+		}
+	}
+}
+
+func TestToGo(t *testing.T) {
+	layout, diags := LookupDialect("go").To("2006-W{isoweek} 15:04 {_15}")
+	want := []Diagnostic{
+		{Offset: 6, Text: "{isoweek}", Message: "extended token, not a layout of the time package"},
+		{Offset: 22, Text: "{_15}", Message: "extended token, not a layout of the time package"},
+	}
+	if layout != "2006-W{isoweek} 15:04 {_15}" || !reflect.DeepEqual(diags, want) {
+		t.Errorf("go To\nwant=%v\ngot= %q %v", want, layout, diags)
+	}
+}
@@ -0,0 +1,130 @@
+package timeformat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Disagreement is an input on which backends give different results.
+type Disagreement struct {
+	Layout  string
+	Op      string    // "format" or "parse"
+	Time    time.Time // the instant formatted, or the one the text came from
+	Input   string    // the text parsed, empty for format
+	Results []Result  // one per backend that supports the layout
+}
+
+// Result is the output of one backend, or its error.
+type Result struct {
+	Backend string
+	Output  string
+	Err     error
+}
+
+func (d Disagreement) String() string {
+	var b strings.Builder
+	if d.Op == "parse" {
+		fmt.Fprintf(&b, "parse %q with %q:\n", d.Input, d.Layout)
+	} else {
+		fmt.Fprintf(&b, "format %s with %q:\n", d.Time.Format(exampleLayout), d.Layout)
+	}
+	for _, r := range d.Results {
+		if r.Err != nil {
+			fmt.Fprintf(&b, "  %-10s error: %v\n", r.Backend, r.Err)
+		} else {
+			fmt.Fprintf(&b, "  %-10s %q\n", r.Backend, r.Output)
+		}
+	}
+	return b.String()
+}
+
+// Differ formats boundary instants with layout in each backend and parses
+// each distinct agreed text back, reporting every input the backends
+// disagree on. Parse results are compared as the instant, offset and zone
+// name they give. Backends that return ErrUnsupported for the layout are left out;
+// with no backends given all registered ones are compared.
+func Differ(layout string, list ...Backend) []Disagreement {
+	if len(list) == 0 {
+		list = Backends()
+	}
+	var found []Disagreement
+	seen := map[string]bool{}
+	for _, t := range boundaries() {
+		d := Disagreement{Layout: layout, Op: "format", Time: t}
+		for _, b := range list {
+			out, err := b.Format(t, layout)
+			if errors.Is(err, ErrUnsupported) {
+				continue
+			}
+			d.Results = append(d.Results, Result{Backend: b.Name(), Output: out, Err: err})
+		}
+		if len(d.Results) < 2 {
+			// nothing to compare against
+			return found
+		}
+		if !agree(d.Results) {
+			found = append(found, d)
+			continue
+		}
+		if d.Results[0].Err != nil || seen[d.Results[0].Output] {
+			continue
+		}
+		seen[d.Results[0].Output] = true
+		p := Disagreement{Layout: layout, Op: "parse", Time: t, Input: d.Results[0].Output}
+		for _, b := range list {
+			got, err := b.Parse(layout, p.Input)
+			if errors.Is(err, ErrUnsupported) {
+				continue
+			}
+			p.Results = append(p.Results, Result{Backend: b.Name(), Output: got.Format(exampleLayout), Err: err})
+		}
+		if !agree(p.Results) {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// agree reports whether all results are the same output, or all errors.
+func agree(results []Result) bool {
+	for _, r := range results[1:] {
+		if (r.Err == nil) != (results[0].Err == nil) || r.Err == nil && r.Output != results[0].Output {
+			return false
+		}
+	}
+	return true
+}
+
+// boundary values Differ combines into instants.
+var (
+	boundaryDates = [][3]int{
+		{2021, 3, 7}, {2020, 12, 31}, {2021, 1, 1}, {2021, 1, 3}, {2021, 1, 4},
+		{2024, 2, 29}, {1999, 12, 31}, {2000, 1, 1}, {1, 1, 1}, {9999, 12, 31},
+	}
+	boundaryClocks = [][4]int{
+		{14, 5, 9, 120000000}, {0, 0, 0, 0}, {12, 0, 0, 0}, {23, 59, 59, 999999999}, {9, 7, 5, 123000},
+	}
+	boundaryZones = []*time.Location{
+		time.UTC,
+		time.FixedZone("CET", 3600),
+		time.FixedZone("", -5*3600-30*60),
+		time.FixedZone("NPT", 5*3600+45*60),
+	}
+)
+
+// boundaries returns every combination of the boundary dates, clocks and
+// zones: ends of months and years, ISO week edges, leap days, the first
+// and last four-digit years, midnight and noon.
+func boundaries() []time.Time {
+	var ts []time.Time
+	for _, d := range boundaryDates {
+		for _, c := range boundaryClocks {
+			for _, loc := range boundaryZones {
+				ts = append(ts, time.Date(d[0], time.Month(d[1]), d[2], c[0], c[1], c[2], c[3], loc))
+			}
+		}
+	}
+	return ts
+}
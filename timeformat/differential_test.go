@@ -0,0 +1,84 @@
+package timeformat
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// upperBackend formats in upper case, to have something to disagree with.
+type upperBackend struct{}
+
+func (upperBackend) Name() string { return "upper" }
+
+func (upperBackend) Format(t time.Time, layout string) (string, error) {
+	if strings.Contains(layout, "{") {
+		return "", ErrUnsupported
+	}
+	return strings.ToUpper(t.Format(layout)), nil
+}
+
+func (upperBackend) Parse(layout, s string) (time.Time, error) {
+	return time.Parse(layout, s)
+}
+
+func TestDiffer(t *testing.T) {
+	testData := []struct {
+		Layout   string
+		Backends []string
+		Format   bool // disagree on formatting
+		Parse    bool // disagree on parsing
+	}{
+		{Layout: time.RFC3339, Backends: []string{"go", "timefmt"}},
+		{Layout: time.DateTime, Backends: []string{"go", "timefmt"}},
+		{Layout: time.Kitchen, Backends: []string{"go", "timefmt"}, Parse: true},
+		{Layout: time.Stamp, Backends: []string{"go", "timefmt"}, Parse: true},
+		{Layout: "2006 002", Backends: []string{"go", "timefmt", "upper"}},
+		{Layout: "Jan 2006", Backends: []string{"go", "upper"}, Format: true},
+		{Layout: "{unix}", Backends: []string{"go", "upper"}},
+	}
+	for _, test := range testData {
+		var list []Backend
+		for _, name := range test.Backends {
+			if name == "upper" {
+				list = append(list, upperBackend{})
+			} else {
+				list = append(list, LookupBackend(name))
+			}
+		}
+		var format, parse bool
+		for _, d := range Differ(test.Layout, list...) {
+			format = format || d.Op == "format"
+			parse = parse || d.Op == "parse"
+		}
+		if format != test.Format || parse != test.Parse {
+			t.Errorf("Differ %q %v want format=%v parse=%v, got format=%v parse=%v",
+				test.Layout, test.Backends, test.Format, test.Parse, format, parse)
+		}
+	}
+}
+
+func TestDisagreementString(t *testing.T) {
+	ds := Differ("Jan", LookupBackend("go"), upperBackend{})
+	if len(ds) == 0 {
+		t.Fatal("Differ found nothing")
+	}
+	want := "format 2021-03-07T14:05:09.12+00:00:00 UTC with \"Jan\":\n  go         \"Mar\"\n  upper      \"MAR\"\n"
+	if got := ds[0].String(); got != want {
+		t.Errorf("Disagreement.String\nwant=%q\ngot= %q", want, got)
+	}
+}
+
+func TestBackends(t *testing.T) {
+	for _, name := range []string{"go", "timefmt"} {
+		if b := LookupBackend(name); b == nil || b.Name() != name {
+			t.Errorf("LookupBackend %q got %v", name, b)
+		}
+	}
+	if _, err := LookupBackend("go").Format(time.Now(), "{unix}"); err != ErrUnsupported {
+		t.Errorf("go backend with an extended token: %v", err)
+	}
+	if _, err := LookupBackend("timefmt").Format(time.Now(), "Jan {2nd}"); err != ErrUnsupported {
+		t.Errorf("timefmt backend with a lossy translation: %v", err)
+	}
+}
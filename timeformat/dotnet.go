@@ -0,0 +1,349 @@
+package timeformat
+
+import "strings"
+
+// dotnetStandard holds the standard format strings, a single specifier
+// that stands for a custom format. The culture-specific ones are given for
+// the invariant culture.
+var dotnetStandard = map[byte]struct {
+	format  string
+	message string
+}{
+	'o': {"yyyy'-'MM'-'dd'T'HH':'mm':'ss'.'fffffffK", ""},
+	'O': {"yyyy'-'MM'-'dd'T'HH':'mm':'ss'.'fffffffK", ""},
+	'r': {"ddd, dd MMM yyyy HH':'mm':'ss 'GMT'", ".NET converts the time to UTC first"},
+	'R': {"ddd, dd MMM yyyy HH':'mm':'ss 'GMT'", ".NET converts the time to UTC first"},
+	's': {"yyyy'-'MM'-'dd'T'HH':'mm':'ss", ""},
+	'u': {"yyyy'-'MM'-'dd HH':'mm':'ss'Z'", ".NET converts the time to UTC first"},
+	'U': {"dddd, dd MMMM yyyy HH:mm:ss", ".NET converts the time to UTC first"},
+	'd': {"MM/dd/yyyy", dotnetCulture},
+	'D': {"dddd, dd MMMM yyyy", dotnetCulture},
+	'f': {"dddd, dd MMMM yyyy HH:mm", dotnetCulture},
+	'F': {"dddd, dd MMMM yyyy HH:mm:ss", dotnetCulture},
+	'g': {"MM/dd/yyyy HH:mm", dotnetCulture},
+	'G': {"MM/dd/yyyy HH:mm:ss", dotnetCulture},
+	'm': {"MMMM dd", dotnetCulture},
+	'M': {"MMMM dd", dotnetCulture},
+	't': {"HH:mm", dotnetCulture},
+	'T': {"HH:mm:ss", dotnetCulture},
+	'y': {"yyyy MMMM", dotnetCulture},
+	'Y': {"yyyy MMMM", dotnetCulture},
+}
+
+const dotnetCulture = "culture-specific standard format, expanded for the invariant culture"
+
+// dotnetField returns the token a run of n specifiers c formats as, with a
+// message if the token is not exact. A zero token means there is none.
+func dotnetField(c byte, n int) (Token, string) {
+	switch c {
+	case 'y':
+		switch n {
+		case 1:
+			return Token{Year, "06"}, "no layout token for an unpadded two-digit year"
+		case 2:
+			return Token{Year, "06"}, ""
+		case 4:
+			return Token{LongYear, "2006"}, ""
+		}
+		return Token{LongYear, "2006"}, "no layout token for this year width"
+	case 'M':
+		switch n {
+		case 1:
+			return Token{NumMonth, "1"}, ""
+		case 2:
+			return Token{ZeroMonth, "01"}, ""
+		case 3:
+			return Token{Month, "Jan"}, ""
+		}
+		return Token{LongMonth, "January"}, ""
+	case 'd':
+		switch n {
+		case 1:
+			return Token{Day, "2"}, ""
+		case 2:
+			return Token{ZeroDay, "02"}, ""
+		case 3:
+			return Token{WeekDay, "Mon"}, ""
+		}
+		return Token{LongWeekDay, "Monday"}, ""
+	case 'H':
+		if n == 1 {
+			return Token{Hour, "15"}, "no layout token for an unpadded 24-hour clock"
+		}
+		return Token{Hour, "15"}, ""
+	case 'h':
+		if n == 1 {
+			return Token{Hour12, "3"}, ""
+		}
+		return Token{ZeroHour12, "03"}, ""
+	case 'm':
+		if n == 1 {
+			return Token{Minute, "4"}, ""
+		}
+		return Token{ZeroMinute, "04"}, ""
+	case 's':
+		if n == 1 {
+			return Token{Second, "5"}, ""
+		}
+		return Token{ZeroSecond, "05"}, ""
+	case 't':
+		if n == 1 {
+			return Token{PM, "PM"}, "no layout token for the first letter of AM/PM"
+		}
+		return Token{PM, "PM"}, ""
+	case 'z':
+		switch n {
+		case 1:
+			return Token{NumShortTZ, "-07"}, "no layout token for an unpadded offset"
+		case 2:
+			return Token{NumShortTZ, "-07"}, ""
+		}
+		return Token{NumColonTZ, "-07:00"}, ""
+	case 'K':
+		// Go times always have a location, so K never prints nothing
+		return Token{ISO8601ColonTZ, "Z07:00"}, ""
+	case 'g':
+		return Token{}, "no layout token for eras"
+	}
+	return Token{}, ""
+}
+
+// FromDotNet translates a .NET custom or standard date and time format
+// string into a layout. Quoted text and characters after a backslash are
+// copied, the separators ':' and '/' are those of the invariant culture,
+// and specifiers with no exact counterpart are reported and translated to
+// the closest token, or kept as literal text.
+func FromDotNet(format string) (string, []Diagnostic) {
+	var b builder
+	if len(format) == 1 {
+		if standard, ok := dotnetStandard[format[0]]; ok {
+			if standard.message != "" {
+				b.lossy(0, format, standard.message)
+			}
+			fromDotNet(&b, standard.format, 0)
+			return b.layout()
+		}
+	}
+	fromDotNet(&b, format, -1)
+	return b.layout()
+}
+
+// fromDotNet adds the tokens of format to b. Standard formats are expanded
+// with all offsets set to at.
+func fromDotNet(b *builder, format string, at int) {
+	for i := 0; i < len(format); {
+		offset := at
+		if at < 0 {
+			offset = i
+		}
+		c := format[i]
+		switch c {
+		case '\'', '"':
+			j := strings.IndexByte(format[i+1:], c)
+			if j < 0 {
+				b.literal(offset, format[i+1:])
+				b.lossy(offset, format[i:], "unterminated quote")
+				return
+			}
+			b.literal(offset, format[i+1:i+1+j])
+			i += j + 2
+			continue
+		case '\\':
+			if i+1 < len(format) {
+				b.literal(offset, format[i+1:i+2])
+			}
+			i += 2
+			continue
+		case '%':
+			// makes a single specifier a custom format
+			i++
+			continue
+		}
+		if strings.IndexByte("yMdHhmstzKgfF", c) < 0 {
+			b.literal(offset, format[i:i+1])
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(format) && format[j] == c {
+			j++
+		}
+		text, n := format[i:j], j-i
+		i = j
+		if c == 'f' || c == 'F' {
+			dotnetFraction(b, offset, text)
+			continue
+		}
+		token, message := dotnetField(c, n)
+		if message != "" {
+			b.lossy(offset, text, message)
+		}
+		if token.Text == "" {
+			b.literal(offset, text)
+		} else {
+			b.token(offset, token.Kind, token.Text)
+		}
+	}
+}
+
+// dotnetFraction adds the fraction of a run of f or F specifiers. Like
+// Go's 9 digits, F omits trailing zeros and .NET drops a '.' before it
+// when there are no digits left.
+func dotnetFraction(b *builder, offset int, text string) {
+	n := len(text)
+	if n > 7 {
+		b.lossy(offset, text, ".NET has at most seven fraction digits")
+		n = 7
+	}
+	kind := FracSecond0
+	if text[0] == 'F' {
+		kind = FracSecond9
+	}
+	switch b.fraction(offset, kind, n) {
+	case '.':
+		return
+	case ',':
+		if kind == FracSecond9 {
+			b.lossy(offset, text, ".NET keeps a ',' before a zero fraction")
+		}
+		return
+	}
+	switch {
+	case n <= 3:
+		b.token(offset, Milliseconds, "{frac3}")
+	case n <= 6:
+		b.token(offset, Microseconds, "{frac6}")
+	default:
+		b.token(offset, Nanoseconds, "{frac9}")
+	}
+	if n != 3 && n != 6 || kind == FracSecond9 {
+		b.lossy(offset, text, "no layout token for this fraction without a separator")
+	}
+}
+
+// ToDotNet translates a layout into a .NET custom format string. Tokens with
+// no exact counterpart are reported and translated to the closest
+// specifiers.
+func ToDotNet(layout string) (string, []Diagnostic) {
+	var out strings.Builder
+	var diags []Diagnostic
+	lossy := func(offset int, token Token, message string) {
+		diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: message})
+	}
+	offset := 0
+	for _, token := range Tokenize(layout) {
+		switch token.Kind {
+		case Literal:
+			out.WriteString(quoteDotNet(token.Text))
+		case LongYear:
+			out.WriteString("yyyy")
+		case Year:
+			out.WriteString("yy")
+		case LongMonth:
+			out.WriteString("MMMM")
+		case Month:
+			out.WriteString("MMM")
+		case NumMonth:
+			out.WriteString("M")
+		case ZeroMonth:
+			out.WriteString("MM")
+		case LongWeekDay:
+			out.WriteString("dddd")
+		case WeekDay:
+			out.WriteString("ddd")
+		case Day:
+			out.WriteString("d")
+		case ZeroDay:
+			out.WriteString("dd")
+		case Hour:
+			out.WriteString("HH")
+		case Hour12:
+			out.WriteString("h")
+		case ZeroHour12:
+			out.WriteString("hh")
+		case Minute:
+			out.WriteString("m")
+		case ZeroMinute:
+			out.WriteString("mm")
+		case Second:
+			out.WriteString("s")
+		case ZeroSecond:
+			out.WriteString("ss")
+		case PM:
+			out.WriteString("tt")
+		case ISO8601ColonTZ:
+			out.WriteString("K")
+		case NumColonTZ:
+			out.WriteString("zzz")
+		case NumShortTZ:
+			out.WriteString("zz")
+		case ISO8601TZ, ISO8601ShortTZ, ISO8601SecondsTZ, ISO8601ColonSecondsTZ:
+			out.WriteString("K")
+			lossy(offset, token, ".NET has no such offset form, using K")
+		case TZ, NumTZ, NumSecondsTZ, NumColonSecondsTZ:
+			out.WriteString("zzz")
+			lossy(offset, token, ".NET has no such zone form, using zzz")
+		case FracSecond0, FracSecond9:
+			n := token.Digits()
+			if n > 7 {
+				lossy(offset, token, ".NET has at most seven fraction digits")
+				n = 7
+			}
+			out.WriteByte(token.Separator())
+			if token.Kind == FracSecond9 {
+				out.WriteString(strings.Repeat("F", n))
+				if token.Separator() == ',' {
+					lossy(offset, token, ".NET keeps a ',' before a zero fraction")
+				}
+			} else {
+				out.WriteString(strings.Repeat("f", n))
+			}
+		case Milliseconds:
+			out.WriteString("fff")
+		case Microseconds:
+			out.WriteString("ffffff")
+		case Nanoseconds:
+			out.WriteString("fffffff")
+			lossy(offset, token, ".NET has at most seven fraction digits")
+		case LowerPM:
+			out.WriteString("tt")
+			lossy(offset, token, ".NET has no lower-case AM/PM")
+		case UnderDay, UnderHour, UnderHour12:
+			out.WriteString(map[Kind]string{UnderDay: "d", UnderHour: "H", UnderHour12: "h"}[token.Kind])
+			lossy(offset, token, ".NET cannot pad with spaces")
+		default:
+			out.WriteString(quoteDotNet(token.Text))
+			lossy(offset, token, "no .NET format specifier")
+		}
+		offset += len(token.Text)
+	}
+	format := out.String()
+	if len(format) == 1 {
+		if _, ok := dotnetStandard[format[0]]; ok {
+			// a single specifier would be read as a standard format
+			format = "%" + format
+		}
+	}
+	return format, diags
+}
+
+// quoteDotNet quotes text that has letters or escape characters.
+func quoteDotNet(text string) string {
+	special := func(r rune) bool {
+		return r < 0x80 && isJavaLetter(byte(r)) || strings.ContainsRune(`'"\%`, r)
+	}
+	if !strings.ContainsFunc(text, special) {
+		return text
+	}
+	if !strings.ContainsAny(text, `'\`) {
+		return "'" + text + "'"
+	}
+	var out strings.Builder
+	for _, r := range text {
+		if special(r) {
+			out.WriteByte('\\')
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
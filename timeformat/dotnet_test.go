@@ -0,0 +1,85 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromDotNet(t *testing.T) {
+	testData := []struct {
+		DotNet string
+		Layout string
+		Lossy  bool
+	}{
+		{DotNet: "yyyy-MM-ddTHH:mm:ss.fffffffK", Layout: "2006-01-02T15:04:05.0000000Z07:00"},
+		{DotNet: "ddd, dd MMM yyyy", Layout: "Mon, 02 Jan 2006"},
+		{DotNet: "o", Layout: "2006-01-02T15:04:05.0000000Z07:00"},
+		{DotNet: "s", Layout: "2006-01-02T15:04:05"},
+		{DotNet: "HH:mm:ss.FFF", Layout: "15:04:05.999"},
+		{DotNet: "HH:mm:ss,fff zzz", Layout: "15:04:05,000 -07:00"},
+		{DotNet: "dddd, MMMM d 'at' h:mm tt", Layout: "Monday, January 2 at 3:04 PM"},
+		{DotNet: `\d\a\y "d" hhmmssfff`, Layout: "day d 030405{frac3}"},
+		{DotNet: "%d", Layout: "2"},
+		{DotNet: "r", Layout: "Mon, 02 Jan 2006 15:04:05 GMT", Lossy: true},
+		{DotNet: "u", Layout: "2006-01-02 15:04:05Z", Lossy: true},
+		{DotNet: "d", Layout: "01/02/2006", Lossy: true},
+		{DotNet: "HH:mm:ss,FFF", Layout: "15:04:05,999", Lossy: true},
+		{DotNet: "H:mm t", Layout: "15:04 PM", Lossy: true},
+		{DotNet: "yyyy g", Layout: "2006 g", Lossy: true},
+		{DotNet: "ss.ffffffff", Layout: "05.0000000", Lossy: true},
+		{DotNet: "'2006", Layout: "2006", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromDotNet(test.DotNet)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromDotNet %q\nwant=%q lossy=%v\ngot= %q %v", test.DotNet, test.Layout, test.Lossy, layout, diags)
+		}
+	}
+}
+
+func TestToDotNet(t *testing.T) {
+	testData := []struct {
+		Layout string
+		DotNet string
+		Lossy  bool
+	}{
+		{Layout: "2006-01-02T15:04:05.0000000Z07:00", DotNet: "yyyy-MM-dd'T'HH:mm:ss.fffffffK"},
+		{Layout: time.RFC3339Nano, DotNet: "yyyy-MM-dd'T'HH:mm:ss.FFFFFFFK", Lossy: true},
+		{Layout: time.Kitchen, DotNet: "h:mmtt"},
+		{Layout: "2", DotNet: "%d"},
+		{Layout: "", DotNet: ""},
+		{Layout: "15:04 o'clock", DotNet: `HH:mm \o\'\c\l\o\c\k`},
+		{Layout: "15:04:05,999", DotNet: "HH:mm:ss,FFF", Lossy: true},
+		{Layout: "MST", DotNet: "zzz", Lossy: true},
+	}
+	for _, test := range testData {
+		format, diags := ToDotNet(test.Layout)
+		if format != test.DotNet || (len(diags) > 0) != test.Lossy {
+			t.Errorf("ToDotNet %q\nwant=%q lossy=%v\ngot= %q %v", test.Layout, test.DotNet, test.Lossy, format, diags)
+		}
+	}
+}
+
+// TestDotNetFractions checks f and F against what .NET prints: f keeps
+// trailing zeros, F drops them and drops the '.' with the last digit.
+func TestDotNetFractions(t *testing.T) {
+	testData := []struct {
+		DotNet string
+		Nanos  int
+		Output string
+	}{
+		{DotNet: "ss.fff", Nanos: 120000000, Output: "09.120"},
+		{DotNet: "ss.FFF", Nanos: 120000000, Output: "09.12"},
+		{DotNet: "ss.FFF", Nanos: 0, Output: "09"},
+		{DotNet: "ss.FFF", Nanos: 999999, Output: "09"},
+		{DotNet: "ss.fffffff", Nanos: 123456789, Output: "09.1234567"},
+		{DotNet: "ss.FFFFFFF", Nanos: 100000000, Output: "09.1"},
+	}
+	for _, test := range testData {
+		layout, _ := FromDotNet(test.DotNet)
+		got := Format(time.Date(2021, 3, 7, 14, 5, 9, test.Nanos, time.UTC), layout)
+		if got != test.Output {
+			t.Errorf("FromDotNet %q = %q formats %d ns as %q, want %q", test.DotNet, layout, test.Nanos, got, test.Output)
+		}
+	}
+}
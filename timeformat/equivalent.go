@@ -0,0 +1,118 @@
+package timeformat
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Equivalence is the verdict of Equivalent.
+type Equivalence int
+
+const (
+	NotEquivalent   Equivalence = iota // an instant is formatted differently
+	Equivalent                         // the same fields and literal text
+	MaybeEquivalent                    // every sampled instant is formatted the same
+)
+
+var equivalenceNames = []string{"not equivalent", "equivalent", "maybe equivalent"}
+
+func (e Equivalence) String() string {
+	if e < 0 || int(e) >= len(equivalenceNames) {
+		return "unknown equivalence"
+	}
+	return equivalenceNames[e]
+}
+
+// CompareLayouts reports whether layouts a and b format every instant the
+// same way. When they do not it also returns an instant they format
+// differently.
+//
+// Layouts made of the same fields and literal text are Equivalent. Other
+// layouts are compared on a sample of instants chosen to tell fields
+// apart: one- and two-digit values, 12-hour clock edges, zero and
+// non-zero fractions, UTC and zones with and without a name, negative and
+// five-digit years. Layouts that agree on all of them are only
+// MaybeEquivalent, as other instants may still tell them apart. To compare
+// strftime formats translate them with FromStrftime first.
+func CompareLayouts(a, b string) (Equivalence, time.Time) {
+	return compareLayouts(Compile(a), Compile(b), probes())
+}
+
+// compareLayouts is CompareLayouts on the sample ts.
+func compareLayouts(la, lb *Layout, ts []time.Time) (Equivalence, time.Time) {
+	if sameTokens(canonical(la.tokens), canonical(lb.tokens)) {
+		return Equivalent, time.Time{}
+	}
+	var buf1, buf2 []byte
+	for _, t := range ts {
+		buf1 = la.AppendFormat(buf1[:0], t)
+		buf2 = lb.AppendFormat(buf2[:0], t)
+		if string(buf1) != string(buf2) {
+			return NotEquivalent, t
+		}
+	}
+	return MaybeEquivalent, time.Time{}
+}
+
+// probe values for each field; the first value of each is the base instant.
+var (
+	probeYears   = []int{2021, 1999, 2000, 5, 999, 10000, -1}
+	probeMonths  = []time.Month{3, 1, 10, 12}
+	probeDays    = []int{7, 1, 9, 10, 31}
+	probeHours   = []int{14, 0, 9, 12, 13, 23}
+	probeMinutes = []int{5, 0, 30, 59}
+	probeSeconds = []int{9, 0, 59}
+	probeNanos   = []int{120000000, 0, 123456789, 1000, 500}
+	probeZones   = []*time.Location{
+		time.FixedZone("CET", 3600),
+		time.UTC,
+		time.FixedZone("", -5*3600-30*60),
+		time.FixedZone("NPT", 5*3600+45*60),
+		time.FixedZone("LMT", -17762),
+	}
+)
+
+// probes returns the instants CompareLayouts compares layouts on: the base
+// instant with one field changed at a time, then a fixed sample of
+// combinations.
+func probes() []time.Time {
+	var ts []time.Time
+	date := func(y int, mo time.Month, d, h, mi, s, ns int, loc *time.Location) {
+		ts = append(ts, time.Date(y, mo, d, h, mi, s, ns, loc))
+	}
+	y, mo, d, h, mi, s, ns, loc := probeYears[0], probeMonths[0], probeDays[0], probeHours[0],
+		probeMinutes[0], probeSeconds[0], probeNanos[0], probeZones[0]
+	date(y, mo, d, h, mi, s, ns, loc)
+	for _, v := range probeYears[1:] {
+		date(v, mo, d, h, mi, s, ns, loc)
+	}
+	for _, v := range probeMonths[1:] {
+		date(y, v, d, h, mi, s, ns, loc)
+	}
+	for _, v := range probeDays[1:] {
+		date(y, mo, v, h, mi, s, ns, loc)
+	}
+	for _, v := range probeHours[1:] {
+		date(y, mo, d, v, mi, s, ns, loc)
+	}
+	for _, v := range probeMinutes[1:] {
+		date(y, mo, d, h, v, s, ns, loc)
+	}
+	for _, v := range probeSeconds[1:] {
+		date(y, mo, d, h, mi, v, ns, loc)
+	}
+	for _, v := range probeNanos[1:] {
+		date(y, mo, d, h, mi, s, v, loc)
+	}
+	for _, v := range probeZones[1:] {
+		date(y, mo, d, h, mi, s, ns, v)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		date(probeYears[r.Intn(len(probeYears))], probeMonths[r.Intn(len(probeMonths))],
+			probeDays[r.Intn(len(probeDays))], probeHours[r.Intn(len(probeHours))],
+			probeMinutes[r.Intn(len(probeMinutes))], probeSeconds[r.Intn(len(probeSeconds))],
+			probeNanos[r.Intn(len(probeNanos))], probeZones[r.Intn(len(probeZones))])
+	}
+	return ts
+}
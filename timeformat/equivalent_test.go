@@ -0,0 +1,65 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareLayouts(t *testing.T) {
+	testData := []struct {
+		A, B string
+		Want Equivalence
+	}{
+		{A: "2006 01 02", B: "2006 01 02", Want: Equivalent},
+		{A: "2006 01 02", B: "2006 1 2"},
+		{A: "15:04:05.000", B: "15:04:05.{frac3}", Want: Equivalent},
+		{A: "15:04:05.999", B: "15:04:05.000"},
+		{A: "2006-01-02T15:04:05Z07:00", B: "2006-01-02T15:04:05-07:00"},
+		{A: "02 Jan 06 15:04 MST", B: "02 Jan 06 15:04 -0700"},
+		{A: "3:04PM", B: "15:04PM"},
+		{A: "_2", B: "02"},
+		{A: "{century}06", B: "2006"},
+		{A: "{isoyear}", B: "2006"},
+		{A: "Jan", B: "January"},
+	}
+	for _, test := range testData {
+		got, ce := CompareLayouts(test.A, test.B)
+		if got != test.Want {
+			t.Errorf("CompareLayouts %q %q want=%v, got=%v", test.A, test.B, test.Want, got)
+			continue
+		}
+		if got == NotEquivalent && Format(ce, test.A) == Format(ce, test.B) {
+			t.Errorf("CompareLayouts %q %q counterexample %v formats as %q for both", test.A, test.B, ce, Format(ce, test.A))
+		}
+	}
+}
+
+func TestCompareLayoutsSample(t *testing.T) {
+	// the hours of the sample are all two-digit
+	ts := []time.Time{time.Date(2021, 3, 7, 14, 5, 9, 0, time.UTC), time.Date(2021, 3, 7, 23, 5, 9, 0, time.UTC)}
+	if got, ce := compareLayouts(Compile("15:04"), Compile("{_15}:04"), ts); got != MaybeEquivalent {
+		t.Errorf("compareLayouts want=%v, got=%v %v", MaybeEquivalent, got, ce)
+	}
+	if got, ce := CompareLayouts("15:04", "{_15}:04"); got != NotEquivalent || ce.Hour() >= 10 {
+		t.Errorf("CompareLayouts want=%v, got=%v %v", NotEquivalent, got, ce)
+	}
+}
+
+func TestCompareLayoutsStrftime(t *testing.T) {
+	testData := []struct {
+		Strftime string
+		Layout   string
+		Want     Equivalence
+	}{
+		{Strftime: "%F %T", Layout: time.DateTime, Want: Equivalent},
+		{Strftime: "%b %e %H:%M:%S.%f", Layout: time.StampMicro, Want: Equivalent},
+		{Strftime: "%D", Layout: "01/02/2006"},
+		{Strftime: "%I:%M %p", Layout: time.Kitchen},
+	}
+	for _, test := range testData {
+		layout, _ := FromStrftime(test.Strftime)
+		if got, ce := CompareLayouts(layout, test.Layout); got != test.Want {
+			t.Errorf("CompareLayouts %q %q want=%v, got=%v %v", test.Strftime, test.Layout, test.Want, got, ce)
+		}
+	}
+}
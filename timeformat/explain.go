@@ -0,0 +1,195 @@
+package timeformat
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reference is the instant Explain renders samples with. Unlike the Go
+// reference time every field has a value that tells padded and unpadded,
+// 12-hour and 24-hour forms apart.
+var Reference = time.Date(2021, time.March, 7, 14, 5, 9, 120000000, time.FixedZone("CET", 3600))
+
+// Explanation describes a layout token by token.
+type Explanation struct {
+	Layout    string        `json:"layout"`
+	Tokens    []Description `json:"tokens"`
+	Reference time.Time     `json:"reference"`
+	Sample    string        `json:"sample"` // the layout rendered for Reference
+}
+
+// Description describes one token of a layout.
+type Description struct {
+	Token   string `json:"token"`
+	Kind    Kind   `json:"-"`
+	Meaning string `json:"meaning"`
+	Sample  string `json:"sample"` // the token rendered for Reference
+}
+
+// Explain describes every token of layout and renders a sample of it.
+func Explain(layout string) Explanation {
+	l := Compile(layout)
+	e := Explanation{
+		Layout:    layout,
+		Tokens:    make([]Description, len(l.tokens)),
+		Reference: Reference,
+		Sample:    l.Format(Reference),
+	}
+	for i, token := range l.tokens {
+		single := &Layout{layout: token.Text, tokens: []Token{token}, locale: l.locale}
+		e.Tokens[i] = Description{
+			Token:   token.Text,
+			Kind:    token.Kind,
+			Meaning: meaning(token),
+			Sample:  single.Format(Reference),
+		}
+	}
+	return e
+}
+
+// String renders the explanation one token per line:
+//
+//	03 → hour, 12-hour clock, zero-padded: 02
+func (e Explanation) String() string {
+	var b strings.Builder
+	b.WriteString(strconv.Quote(e.Layout))
+	b.WriteByte('\n')
+	for _, d := range e.Tokens {
+		b.WriteString("  ")
+		if d.Kind == Literal {
+			b.WriteString(strconv.Quote(d.Token))
+		} else {
+			b.WriteString(d.Token)
+		}
+		b.WriteString(" → ")
+		b.WriteString(d.Meaning)
+		if d.Kind != Literal {
+			b.WriteString(": ")
+			b.WriteString(d.Sample)
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString("sample for ")
+	b.WriteString(e.Reference.Format(time.RFC3339Nano))
+	b.WriteString(": ")
+	b.WriteString(e.Sample)
+	return b.String()
+}
+
+// meaning describes a token in words.
+func meaning(token Token) string {
+	switch token.Kind {
+	case Literal:
+		return "literal text"
+	case LongYear:
+		return "four-digit year"
+	case Year:
+		return "two-digit year"
+	case LongMonth:
+		return "month name"
+	case Month:
+		return "month name, abbreviated"
+	case NumMonth:
+		return "month number, no padding"
+	case ZeroMonth:
+		return "month number, zero-padded"
+	case LongWeekDay:
+		return "weekday name"
+	case WeekDay:
+		return "weekday name, abbreviated"
+	case Day:
+		return "day of month, no padding"
+	case UnderDay:
+		return "day of month, space-padded"
+	case ZeroDay:
+		return "day of month, zero-padded"
+	case OrdinalDay:
+		return "day of month as an ordinal"
+	case Century:
+		return "century, two digits"
+	case ISOYear:
+		return "four-digit year of the ISO week"
+	case ShortISOYear:
+		return "two-digit year of the ISO week"
+	case ISOWeek:
+		return "ISO week of the year, zero-padded"
+	case ISOWeekDay:
+		return "weekday number, 1 for Monday to 7 for Sunday"
+	case WeekDayNum:
+		return "weekday number, 0 for Sunday to 6 for Saturday"
+	case SundayWeek:
+		return "week of the year starting on Sunday, zero-padded"
+	case MondayWeek:
+		return "week of the year starting on Monday, zero-padded"
+	case Unix:
+		return "seconds since 1970-01-01 UTC"
+	case UnderYearDay:
+		return "day of year, space-padded to three digits"
+	case ZeroYearDay:
+		return "day of year, zero-padded to three digits"
+	case Hour:
+		return "hour, 24-hour clock, zero-padded"
+	case UnderHour:
+		return "hour, 24-hour clock, space-padded"
+	case Hour12:
+		return "hour, 12-hour clock, no padding"
+	case ZeroHour12:
+		return "hour, 12-hour clock, zero-padded"
+	case UnderHour12:
+		return "hour, 12-hour clock, space-padded"
+	case Minute:
+		return "minute, no padding"
+	case ZeroMinute:
+		return "minute, zero-padded"
+	case Second:
+		return "second, no padding"
+	case ZeroSecond:
+		return "second, zero-padded"
+	case PM:
+		return "uppercase AM/PM"
+	case LowerPM:
+		return "lowercase am/pm"
+	case TZ:
+		return "zone abbreviation"
+	case ISO8601TZ:
+		return "zone offset ±hhmm, Z for UTC"
+	case ISO8601SecondsTZ:
+		return "zone offset ±hhmmss, Z for UTC"
+	case ISO8601ShortTZ:
+		return "zone offset ±hh, Z for UTC"
+	case ISO8601ColonTZ:
+		return "zone offset ±hh:mm, Z for UTC"
+	case ISO8601ColonSecondsTZ:
+		return "zone offset ±hh:mm:ss, Z for UTC"
+	case NumTZ:
+		return "zone offset ±hhmm"
+	case NumSecondsTZ:
+		return "zone offset ±hhmmss"
+	case NumShortTZ:
+		return "zone offset ±hh"
+	case NumColonTZ:
+		return "zone offset ±hh:mm"
+	case NumColonSecondsTZ:
+		return "zone offset ±hh:mm:ss"
+	case FracSecond0:
+		return "fraction of a second, " + digits(token.Digits()) + " after " + strconv.Quote(string(token.Separator()))
+	case FracSecond9:
+		return "fraction of a second, up to " + digits(token.Digits()) + " after " + strconv.Quote(string(token.Separator())) +
+			", trailing zeros dropped"
+	case Milliseconds:
+		return "fraction of a second, 3 digits, no separator"
+	case Microseconds:
+		return "fraction of a second, 6 digits, no separator"
+	case Nanoseconds:
+		return "fraction of a second, 9 digits, no separator"
+	}
+	return "unknown token"
+}
+
+func digits(n int) string {
+	if n == 1 {
+		return "1 digit"
+	}
+	return strconv.Itoa(n) + " digits"
+}
@@ -0,0 +1,47 @@
+package timeformat
+
+import (
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	e := Explain("03:4:5.999999999 pm 06")
+	want := []Description{
+		{Token: "03", Kind: ZeroHour12, Meaning: "hour, 12-hour clock, zero-padded", Sample: "02"},
+		{Token: ":", Kind: Literal, Meaning: "literal text", Sample: ":"},
+		{Token: "4", Kind: Minute, Meaning: "minute, no padding", Sample: "5"},
+		{Token: ":", Kind: Literal, Meaning: "literal text", Sample: ":"},
+		{Token: "5", Kind: Second, Meaning: "second, no padding", Sample: "9"},
+		{Token: ".999999999", Kind: FracSecond9, Meaning: `fraction of a second, up to 9 digits after ".", trailing zeros dropped`, Sample: ".12"},
+		{Token: " ", Kind: Literal, Meaning: "literal text", Sample: " "},
+		{Token: "pm", Kind: LowerPM, Meaning: "lowercase am/pm", Sample: "pm"},
+		{Token: " ", Kind: Literal, Meaning: "literal text", Sample: " "},
+		{Token: "06", Kind: Year, Meaning: "two-digit year", Sample: "21"},
+	}
+	if len(e.Tokens) != len(want) {
+		t.Fatalf("Explain want %d tokens, got %d: %+v", len(want), len(e.Tokens), e.Tokens)
+	}
+	for i := range want {
+		if e.Tokens[i] != want[i] {
+			t.Errorf("Explain token %d\nwant=%+v\ngot= %+v", i, want[i], e.Tokens[i])
+		}
+	}
+	if e.Sample != "02:5:9.12 pm 21" {
+		t.Errorf("Explain sample want=%s, got=%s", "02:5:9.12 pm 21", e.Sample)
+	}
+}
+
+func TestExplainString(t *testing.T) {
+	want := `"_2 Jan 15:04"
+  _2 → day of month, space-padded:  7
+  " " → literal text
+  Jan → month name, abbreviated: Mar
+  " " → literal text
+  15 → hour, 24-hour clock, zero-padded: 14
+  ":" → literal text
+  04 → minute, zero-padded: 05
+sample for 2021-03-07T14:05:09.12+01:00:  7 Mar 14:05`
+	if got := Explain("_2 Jan 15:04").String(); got != want {
+		t.Errorf("Explain\nwant=\n%s\ngot=\n%s", want, got)
+	}
+}
@@ -0,0 +1,36 @@
+//go:build glibc && cgo && linux
+
+package timeformat
+
+import (
+	"time"
+
+	"timeformattest/internal/glibc"
+)
+
+func init() {
+	RegisterBackend(glibcBackend{})
+}
+
+// glibcBackend is the C library as a Backend, given the strftime
+// translation of each layout. It is built with the glibc tag and skips the
+// instants for which glibc.Differs knows glibc to disagree.
+type glibcBackend struct{}
+
+func (glibcBackend) Name() string { return "glibc" }
+
+func (glibcBackend) Format(t time.Time, layout string) (string, error) {
+	format, diags := ToStrftime(layout)
+	if len(diags) > 0 || glibc.Differs(format, t) != "" {
+		return "", ErrUnsupported
+	}
+	return glibc.Strftime(t, format)
+}
+
+func (glibcBackend) Parse(layout, s string) (time.Time, error) {
+	format, diags := ToStrftime(layout)
+	if len(diags) > 0 {
+		return time.Time{}, ErrUnsupported
+	}
+	return glibc.Strptime(s, format)
+}
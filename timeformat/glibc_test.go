@@ -0,0 +1,131 @@
+//go:build glibc && cgo && linux
+
+package timeformat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+
+	"timeformattest/internal/glibc"
+)
+
+func TestGlibcFormatTable(t *testing.T) {
+	for _, test := range formatTests {
+		if test.StrftimeLayout == "" || glibc.Differs(test.StrftimeLayout, test.Timestamp) != "" {
+			continue
+		}
+		want, err := glibc.Strftime(test.Timestamp, test.StrftimeLayout)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if want != test.Expected {
+			t.Errorf("glibc %q for %v\nwant=%q\ngot= %q", test.StrftimeLayout, test.Timestamp, test.Expected, want)
+		}
+		if got := timefmt.Format(test.Timestamp, test.StrftimeLayout); got != want {
+			t.Errorf("timefmt %q for %v\nglibc=%q\ngot=  %q", test.StrftimeLayout, test.Timestamp, want, got)
+		}
+		layout, _ := FromStrftime(test.StrftimeLayout)
+		if got := Format(test.Timestamp, layout); got != want {
+			t.Errorf("strftime %q as %q for %v\nglibc=%q\ngot=  %q", test.StrftimeLayout, layout, test.Timestamp, want, got)
+		}
+	}
+}
+
+func TestGlibcDirectives(t *testing.T) {
+	formats := []string{"%%", "%t", "%n"}
+	for c := range strftime {
+		formats = append(formats, "%"+string(c))
+	}
+	for c := range strftimeComposite {
+		formats = append(formats, "%"+string(c))
+	}
+	for c := range strftimePadded {
+		formats = append(formats, "%-"+string(c), "%_"+string(c), "%0"+string(c))
+	}
+	formats = append(formats, "%^p", "%#p", "%^a", "%#Z", "%02d", "%4Y", "%-a", "%_B")
+
+	instants := []time.Time{
+		time.Date(2021, 1, 3, 0, 5, 9, 1000, time.UTC),
+		time.Date(2020, 12, 31, 9, 0, 0, 120000000, time.FixedZone("", -9000)),
+		time.Date(1999, 6, 14, 12, 59, 59, 999999999, time.FixedZone("CEST", 7200)),
+		time.Date(2024, 2, 29, 23, 30, 1, 0, location("America/New_York")),
+		time.Date(1, 1, 1, 13, 0, 0, 0, time.UTC),
+	}
+	for _, format := range formats {
+		layout, diags := FromStrftime(format)
+		for _, ts := range instants {
+			if glibc.Differs(format, ts) != "" {
+				continue
+			}
+			want, err := glibc.Strftime(ts, format)
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+			if got := timefmt.Format(ts, format); got != want {
+				t.Errorf("timefmt %q for %v\nglibc=%q\ngot=  %q", format, ts, want, got)
+			}
+			if got := Format(ts, layout); len(diags) == 0 && got != want {
+				t.Errorf("strftime %q as %q for %v\nglibc=%q\ngot=  %q", format, layout, ts, want, got)
+			}
+		}
+	}
+}
+
+// TestGlibcParse checks timefmt-go against strptime; timeparse checks the
+// compiled parser of the strftime translation.
+func TestGlibcParse(t *testing.T) {
+	formats := []string{
+		"%Y-%m-%d %H:%M:%S",
+		"%F %T %z",
+		"%d/%b/%Y:%H:%M:%S %z",
+		"%a, %d %b %Y %T %z",
+		"%A %B %e %Y %I:%M:%S %p",
+		"%Y %j %R",
+		"%D %T",
+		"%s",
+	}
+	instants := []time.Time{
+		time.Date(2021, 1, 3, 0, 5, 9, 0, time.UTC),
+		time.Date(2020, 12, 31, 9, 0, 0, 0, time.FixedZone("", -9000)),
+		time.Date(1999, 6, 14, 12, 59, 59, 0, time.FixedZone("CEST", 7200)),
+		time.Date(2024, 2, 29, 23, 30, 1, 0, time.UTC),
+	}
+	for _, format := range formats {
+		for _, ts := range instants {
+			if glibc.Differs(format, ts) != "" {
+				continue
+			}
+			text, _ := glibc.Strftime(ts, format)
+			want, err := glibc.Strptime(text, format)
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+			got, err := timefmt.Parse(text, format)
+			if err != nil {
+				t.Errorf("timefmt %q with %q: %v", text, format, err)
+			} else if !got.Equal(want) {
+				t.Errorf("timefmt %q with %q\nglibc=%v\ngot=  %v", text, format, want, got)
+			}
+		}
+	}
+}
+
+// TestGlibcDiffer requires byte-identical formatting of the named layouts.
+// Parsing differs in zone names, which strptime never sets, and in
+// directives one side ignores, so those disagreements are only logged.
+func TestGlibcDiffer(t *testing.T) {
+	for _, n := range Registry() {
+		for _, d := range Differ(n.Layout, LookupBackend("timefmt"), LookupBackend("glibc")) {
+			if d.Op == "format" {
+				t.Errorf("%s: %s", n.Name, d)
+			} else {
+				t.Logf("%s: %s", n.Name, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package timeformat
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FromICU translates an ICU or CLDR date pattern into a layout. The letters
+// are those of Java without optional sections and the pad modifier.
+func FromICU(pattern string) (string, []Diagnostic) {
+	return fromPattern(pattern, icuFlavor)
+}
+
+// ToICU translates a layout into an ICU date pattern.
+func ToICU(layout string) (string, []Diagnostic) {
+	return toPattern(layout, icuFlavor)
+}
+
+// cldrJSON is a subset of the CLDR data of the Gregorian calendar for the
+// locales of this package.
+//
+//go:embed cldr.json
+var cldrJSON []byte
+
+// cldrLocale is the CLDR data of one locale.
+type cldrLocale struct {
+	Hour             string            `json:"hour"`    // the hour letter the skeleton letter j stands for
+	Decimal          string            `json:"decimal"` // separator of fractional seconds
+	AvailableFormats map[string]string `json:"availableFormats"`
+	DateTimeFormats  map[string]string `json:"dateTimeFormats"` // full, long, medium and short
+}
+
+var cldr map[string]*cldrLocale
+
+func init() {
+	if err := json.Unmarshal(cldrJSON, &cldr); err != nil {
+		panic("timeformat: cldr.json: " + err.Error())
+	}
+}
+
+// icuLocales are the locales with the abbreviations ICU writes in dates,
+// the CLDR format forms, for the locales whose own abbreviations differ.
+var icuLocales = map[*Locale]*Locale{
+	German: withShortNames(German,
+		[12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		[7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."}),
+	French: withShortNames(French,
+		[12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		[7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."}),
+}
+
+func withShortNames(locale *Locale, months [12]string, days [7]string) *Locale {
+	l := *locale
+	l.ShortMonths, l.ShortDays = months, days
+	return &l
+}
+
+// ICULocale returns locale with the abbreviated month and day names ICU
+// writes in dates, for formatting the layouts of FromSkeleton. Locales
+// whose names ICU shares are returned as they are.
+func ICULocale(locale *Locale) *Locale {
+	if l, ok := icuLocales[locale]; ok {
+		return l
+	}
+	return locale
+}
+
+// skeletonField is a run of one letter in a skeleton or pattern.
+type skeletonField struct {
+	letter byte
+	count  int
+}
+
+// kind returns the letter a field is matched by: stand-alone and format
+// forms, the hours of one clock, and all zone letters match each other.
+func (f skeletonField) kind() byte {
+	switch f.letter {
+	case 'L':
+		return 'M'
+	case 'c', 'e':
+		return 'E'
+	case 'K':
+		return 'h'
+	case 'k':
+		return 'H'
+	case 'z', 'v', 'V', 'O', 'X', 'x':
+		return 'v'
+	}
+	return f.letter
+}
+
+// text reports whether a field formats as a name rather than a number.
+func (f skeletonField) text() bool {
+	switch f.letter {
+	case 'M', 'L':
+		return f.count >= 3
+	case 'E':
+		return true
+	case 'c', 'e':
+		return f.count >= 3
+	}
+	return false
+}
+
+// parseSkeleton splits skeleton into fields, replacing j by hour.
+func parseSkeleton(skeleton, hour string) ([]skeletonField, error) {
+	var fields []skeletonField
+	seen := map[byte]bool{}
+	for i := 0; i < len(skeleton); {
+		c := skeleton[i]
+		if !isJavaLetter(c) {
+			return nil, fmt.Errorf("skeleton %q: %q is not a pattern letter", skeleton, c)
+		}
+		j := i + 1
+		for j < len(skeleton) && skeleton[j] == c {
+			j++
+		}
+		f := skeletonField{c, j - i}
+		if c == 'j' {
+			f.letter = hour[0]
+		}
+		if seen[f.kind()] {
+			return nil, fmt.Errorf("skeleton %q: field %q given twice", skeleton, c)
+		}
+		seen[f.kind()] = true
+		fields = append(fields, f)
+		i = j
+	}
+	return fields, nil
+}
+
+// SkeletonPattern returns the ICU pattern locale uses for skeleton, a
+// string of pattern letters such as "yMMMd" naming the fields to show and
+// their widths. As with ICU, the closest pattern of the locale's CLDR data
+// is adjusted to the widths asked for, and skeletons with both date and
+// time fields join a date and a time pattern.
+func SkeletonPattern(skeleton string, locale *Locale) (string, error) {
+	data := cldr[locale.Tag]
+	if data == nil {
+		return "", fmt.Errorf("no CLDR data for locale %s", locale.Tag)
+	}
+	fields, err := parseSkeleton(skeleton, data.Hour)
+	if err != nil {
+		return "", err
+	}
+	var date, clock []skeletonField
+	frac := 0
+	for _, f := range fields {
+		switch {
+		case f.letter == 'a':
+			// the pattern has the marker if its clock needs one
+		case f.letter == 'S':
+			frac = f.count
+		case strings.IndexByte("hHmsv", f.kind()) >= 0:
+			clock = append(clock, f)
+		default:
+			date = append(date, f)
+		}
+	}
+	if frac > 0 && !hasKind(clock, 's') {
+		return "", fmt.Errorf("skeleton %q: fractional seconds without seconds", skeleton)
+	}
+	pattern, ok := data.match(append(date, clock...))
+	if !ok && len(date) > 0 && len(clock) > 0 {
+		d, dok := data.match(date)
+		c, cok := data.match(clock)
+		if ok = dok && cok; ok {
+			joined := data.DateTimeFormats[dateWidth(date)]
+			pattern = strings.NewReplacer("{1}", d, "{0}", c).Replace(joined)
+		}
+	}
+	if !ok {
+		return "", fmt.Errorf("no CLDR pattern for skeleton %q in locale %s", skeleton, locale.Tag)
+	}
+	if frac > 0 {
+		pattern = withFraction(pattern, data.Decimal, frac)
+	}
+	return pattern, nil
+}
+
+// FromSkeleton expands skeleton into the pattern of locale, see
+// SkeletonPattern, and translates it into a layout. Formatting with
+// Compile(layout).WithLocale(ICULocale(locale)) gives the same text as ICU,
+// except that stand-alone names (L and c) are written in their format
+// forms, which German abbreviates differently: ICU writes "LLL" as "Mär"
+// but "MMM" as "März".
+func FromSkeleton(skeleton string, locale *Locale) (string, []Diagnostic, error) {
+	pattern, err := SkeletonPattern(skeleton, locale)
+	if err != nil {
+		return "", nil, err
+	}
+	layout, diags := FromICU(pattern)
+	return layout, diags, nil
+}
+
+func hasKind(fields []skeletonField, kind byte) bool {
+	for _, f := range fields {
+		if f.kind() == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// dateWidth picks the date-time format for date fields the way ICU does,
+// by the width of the month and whether there is a weekday.
+func dateWidth(date []skeletonField) string {
+	for _, f := range date {
+		if f.kind() == 'M' {
+			switch {
+			case f.count >= 4 && hasKind(date, 'E'):
+				return "full"
+			case f.count >= 4:
+				return "long"
+			case f.count == 3:
+				return "medium"
+			}
+		}
+	}
+	return "short"
+}
+
+// match returns the available format with the same fields as fields and
+// the closest widths, adjusted to the widths of fields.
+func (data *cldrLocale) match(fields []skeletonField) (string, bool) {
+	skeletons := make([]string, 0, len(data.AvailableFormats))
+	for s := range data.AvailableFormats {
+		skeletons = append(skeletons, s)
+	}
+	sort.Strings(skeletons)
+	best, bestDistance := "", -1
+	for _, s := range skeletons {
+		candidate, err := parseSkeleton(s, data.Hour)
+		if err != nil || len(candidate) != len(fields) {
+			continue
+		}
+		distance := 0
+		for _, f := range fields {
+			g, ok := findKind(candidate, f.kind())
+			if !ok {
+				distance = -1
+				break
+			}
+			if f.text() != g.text() {
+				distance += 0x100
+			}
+			distance += abs(f.count - g.count)
+		}
+		if distance >= 0 && (bestDistance < 0 || distance < bestDistance) {
+			best, bestDistance = s, distance
+		}
+	}
+	if bestDistance < 0 {
+		return "", false
+	}
+	return adjustWidths(data.AvailableFormats[best], fields), true
+}
+
+func findKind(fields []skeletonField, kind byte) (skeletonField, bool) {
+	for _, f := range fields {
+		if f.kind() == kind {
+			return f, true
+		}
+	}
+	return skeletonField{}, false
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// adjustWidths rewrites the fields of pattern to the widths of fields. As
+// in ICU, hours, minutes and seconds keep the width of the pattern, names
+// stay names and numbers stay numbers; zones take the letter asked for.
+func adjustWidths(pattern string, fields []skeletonField) string {
+	var out strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '\'' {
+			j := i + 1
+			for j < len(pattern) && pattern[j] != '\'' {
+				j++
+			}
+			out.WriteString(pattern[i:min(j+1, len(pattern))])
+			i = j + 1
+			continue
+		}
+		if !isJavaLetter(c) {
+			out.WriteByte(c)
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(pattern) && pattern[j] == c {
+			j++
+		}
+		have := skeletonField{c, j - i}
+		want, ok := findKind(fields, have.kind())
+		switch {
+		case !ok || strings.IndexByte("hHms", have.kind()) >= 0 || want.text() != have.text():
+		case have.kind() == 'v':
+			have = want
+		default:
+			have.count = want.count
+		}
+		out.WriteString(strings.Repeat(string(have.letter), have.count))
+		i = j
+	}
+	return out.String()
+}
+
+// withFraction appends count fraction digits after the seconds of pattern.
+func withFraction(pattern, decimal string, count int) string {
+	i := strings.LastIndexByte(pattern, 's')
+	return pattern[:i+1] + decimal + strings.Repeat("S", count) + pattern[i+1:]
+}
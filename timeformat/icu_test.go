@@ -0,0 +1,126 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromICU(t *testing.T) {
+	testData := []struct {
+		ICU    string
+		Layout string
+		Lossy  bool
+	}{
+		{ICU: "MMM d, y", Layout: "Jan 2, 2006"},
+		{ICU: "EEEE, d. MMMM y 'um' HH:mm", Layout: "Monday, 2. January 2006 um 15:04"},
+		{ICU: "h:mm:ss aaa [z]", Layout: "3:04:05 PM [MST]"},
+		{ICU: "HH:mm:ss,SSS", Layout: "15:04:05,000"},
+		{ICU: "ccc LLL", Layout: "Mon Jan"},
+		{ICU: "ppd", Layout: "pp2", Lossy: true},
+		{ICU: "h:mm a v", Layout: "3:04 PM MST", Lossy: true},
+		{ICU: "QQQ y", Layout: "QQQ 2006", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromICU(test.ICU)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromICU %q\nwant=%q lossy=%v\ngot= %q %v", test.ICU, test.Layout, test.Lossy, layout, diags)
+		}
+	}
+}
+
+func TestToICU(t *testing.T) {
+	testData := []struct {
+		Layout string
+		ICU    string
+		Lossy  bool
+	}{
+		{Layout: "Jan 2, 2006 [15:04]", ICU: "MMM d, yyyy [HH:mm]"},
+		{Layout: time.RFC3339, ICU: "yyyy-MM-dd'T'HH:mm:ssXXX"},
+		{Layout: "_2 {_15}", ICU: "d H", Lossy: true},
+	}
+	for _, test := range testData {
+		pattern, diags := ToICU(test.Layout)
+		if pattern != test.ICU || (len(diags) > 0) != test.Lossy {
+			t.Errorf("ToICU %q\nwant=%q lossy=%v\ngot= %q %v", test.Layout, test.ICU, test.Lossy, pattern, diags)
+		}
+	}
+}
+
+func TestSkeleton(t *testing.T) {
+	at := time.Date(2021, 3, 7, 14, 5, 9, 123456789, time.UTC)
+	testData := []struct {
+		Skeleton string
+		Locale   *Locale
+		Pattern  string
+		Output   string
+	}{
+		{Skeleton: "yMMMd", Locale: English, Pattern: "MMM d, y", Output: "Mar 7, 2021"},
+		{Skeleton: "yMMMd", Locale: German, Pattern: "d. MMM y", Output: "7. März 2021"},
+		{Skeleton: "yMMMEd", Locale: German, Pattern: "E, d. MMM y", Output: "So., 7. März 2021"},
+		{Skeleton: "yMMMEd", Locale: French, Pattern: "E d MMM y", Output: "dim. 7 mars 2021"},
+		{Skeleton: "yMMMd", Locale: French, Pattern: "d MMM y", Output: "7 mars 2021"},
+		{Skeleton: "yMMMMEEEEd", Locale: English, Pattern: "EEEE, MMMM d, y", Output: "Sunday, March 7, 2021"},
+		{Skeleton: "yMMdd", Locale: English, Pattern: "MM/dd/y", Output: "03/07/2021"},
+		{Skeleton: "yMMdd", Locale: German, Pattern: "dd.MM.y", Output: "07.03.2021"},
+		{Skeleton: "jm", Locale: English, Pattern: "h:mm\u202fa", Output: "2:05\u202fPM"},
+		{Skeleton: "jm", Locale: German, Pattern: "HH:mm", Output: "14:05"},
+		{Skeleton: "Hmsz", Locale: English, Pattern: "HH:mm:ss z", Output: "14:05:09 UTC"},
+		{Skeleton: "HmsSSS", Locale: French, Pattern: "HH:mm:ss,SSS", Output: "14:05:09,123"},
+		{Skeleton: "yMMMdjm", Locale: English, Pattern: "MMM d, y, h:mm\u202fa", Output: "Mar 7, 2021, 2:05\u202fPM"},
+		{Skeleton: "yMMMMdHm", Locale: French, Pattern: "d MMMM y 'à' HH:mm", Output: "7 mars 2021 à 14:05"},
+	}
+	for _, test := range testData {
+		pattern, err := SkeletonPattern(test.Skeleton, test.Locale)
+		if err != nil || pattern != test.Pattern {
+			t.Errorf("SkeletonPattern %q %s\nwant=%q\ngot= %q %v", test.Skeleton, test.Locale.Tag, test.Pattern, pattern, err)
+			continue
+		}
+		layout, diags, _ := FromSkeleton(test.Skeleton, test.Locale)
+		if got := Compile(layout).WithLocale(ICULocale(test.Locale)).Format(at); got != test.Output || len(diags) > 0 {
+			t.Errorf("FromSkeleton %q %s = %q\nwant=%q\ngot= %q %v", test.Skeleton, test.Locale.Tag, layout, test.Output, got, diags)
+		}
+	}
+
+	for _, skeleton := range []string{"yMMMdd-", "yyMy", "Qm", "Sm"} {
+		if pattern, err := SkeletonPattern(skeleton, English); err == nil {
+			t.Errorf("SkeletonPattern %q, want error, got=%q", skeleton, pattern)
+		}
+	}
+	if _, err := SkeletonPattern("yMd", &Locale{Tag: "xx"}); err == nil {
+		t.Errorf("SkeletonPattern for a locale without CLDR data, want error")
+	}
+}
+
+func TestICULocale(t *testing.T) {
+	if got := ICULocale(German); got.ShortMonths[2] != "März" || got.ShortDays[1] != "Mo." || got.Ordinal(7) != "7." {
+		t.Errorf("ICULocale(German) = %+v", got)
+	}
+	if German.ShortMonths[2] != "Mär" {
+		t.Errorf("ICULocale changed German to %q", German.ShortMonths)
+	}
+	if got := ICULocale(English); got != English {
+		t.Errorf("ICULocale(English) is a copy")
+	}
+}
+
+// TestCLDRPatterns translates every pattern of the embedded CLDR data.
+func TestCLDRPatterns(t *testing.T) {
+	for tag, data := range cldr {
+		if LookupLocale(tag) == nil {
+			t.Errorf("CLDR data for unknown locale %s", tag)
+		}
+		for skeleton, pattern := range data.AvailableFormats {
+			if _, err := parseSkeleton(skeleton, data.Hour); err != nil {
+				t.Errorf("%s: %v", tag, err)
+			}
+			_, diags := FromICU(pattern)
+			for _, d := range diags {
+				switch d.Text {
+				case "G", "v":
+				default:
+					t.Errorf("%s %s: FromICU %q: %v", tag, skeleton, pattern, d)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,475 @@
+package timeformat
+
+import "strings"
+
+// javaPadded gives the token of a field preceded by the pad modifier 'p',
+// which pads the field with spaces to the number of p letters.
+var javaPadded = map[string]Token{
+	"ppd":  {UnderDay, "_2"},
+	"pppD": {UnderYearDay, "__2"},
+	"ppH":  {UnderHour, "{_15}"},
+	"pph":  {UnderHour12, "{_3}"},
+}
+
+// javaWeeks is the diagnostic for the week fields, which Java and ICU take
+// from the locale and this package from ISO 8601.
+const javaWeeks = "week fields depend on the locale, translated as ISO 8601 weeks"
+
+// flavor is a language of pattern letters: Java, ICU and date-fns share
+// most of them.
+type flavor int
+
+const (
+	javaFlavor flavor = iota
+	icuFlavor
+	dateFnsFlavor
+)
+
+// javaField returns the token a run of n pattern letters c formats as, with
+// a message if the token is not exact. A zero token means there is none.
+func javaField(c byte, n int, f flavor) (Token, string) {
+	if f == dateFnsFlavor {
+		if token, message, ok := dateFnsField(c, n); ok {
+			return token, message
+		}
+	}
+	switch c {
+	case 'y', 'u':
+		switch {
+		case n == 2:
+			return Token{Year, "06"}, ""
+		case n == 4:
+			return Token{LongYear, "2006"}, ""
+		case n == 1 && f != javaFlavor:
+			// CLDR writes every full year as y, the padding only shows
+			// before the year 1000
+			return Token{LongYear, "2006"}, ""
+		}
+		return Token{LongYear, "2006"}, "no layout token for this year width, Go pads years to four digits"
+	case 'Y':
+		if n == 2 {
+			return Token{ShortISOYear, "{isoyear2}"}, javaWeeks
+		}
+		return Token{ISOYear, "{isoyear}"}, javaWeeks
+	case 'w':
+		return Token{ISOWeek, "{isoweek}"}, javaWeeks
+	case 'M', 'L':
+		switch n {
+		case 1:
+			return Token{NumMonth, "1"}, ""
+		case 2:
+			return Token{ZeroMonth, "01"}, ""
+		case 3:
+			return Token{Month, "Jan"}, ""
+		case 4:
+			return Token{LongMonth, "January"}, ""
+		}
+		return Token{Month, "Jan"}, "no layout token for narrow month names"
+	case 'd':
+		switch n {
+		case 1:
+			return Token{Day, "2"}, ""
+		case 2:
+			return Token{ZeroDay, "02"}, ""
+		}
+	case 'D':
+		switch n {
+		case 1, 2:
+			return Token{ZeroYearDay, "002"}, "no layout token for day of year with less than three digits"
+		case 3:
+			return Token{ZeroYearDay, "002"}, ""
+		}
+	case 'E':
+		switch n {
+		case 1, 2, 3:
+			return Token{WeekDay, "Mon"}, ""
+		case 4:
+			return Token{LongWeekDay, "Monday"}, ""
+		}
+		return Token{WeekDay, "Mon"}, "no layout token for narrow day names"
+	case 'e', 'c':
+		switch n {
+		case 1, 2:
+			return Token{ISOWeekDay, "{weekday}"}, javaWeeks
+		case 3:
+			return Token{WeekDay, "Mon"}, ""
+		case 4:
+			return Token{LongWeekDay, "Monday"}, ""
+		}
+		return Token{WeekDay, "Mon"}, "no layout token for narrow day names"
+	case 'a':
+		if n == 1 || f == icuFlavor && n <= 3 {
+			return Token{PM, "PM"}, ""
+		}
+	case 'H':
+		switch n {
+		case 1:
+			return Token{Hour, "15"}, "no layout token for an unpadded 24-hour clock"
+		case 2:
+			return Token{Hour, "15"}, ""
+		}
+	case 'k':
+		return Token{Hour, "15"}, "no layout token for the 1-24 clock"
+	case 'h':
+		switch n {
+		case 1:
+			return Token{Hour12, "3"}, ""
+		case 2:
+			return Token{ZeroHour12, "03"}, ""
+		}
+	case 'K':
+		return Token{ZeroHour12, "03"}, "no layout token for the 0-11 clock"
+	case 'm':
+		switch n {
+		case 1:
+			return Token{Minute, "4"}, ""
+		case 2:
+			return Token{ZeroMinute, "04"}, ""
+		}
+	case 's':
+		switch n {
+		case 1:
+			return Token{Second, "5"}, ""
+		case 2:
+			return Token{ZeroSecond, "05"}, ""
+		}
+	case 'X', 'x':
+		tokens := [][2]Token{
+			{{ISO8601ShortTZ, "Z07"}, {NumShortTZ, "-07"}},
+			{{ISO8601TZ, "Z0700"}, {NumTZ, "-0700"}},
+			{{ISO8601ColonTZ, "Z07:00"}, {NumColonTZ, "-07:00"}},
+			{{ISO8601SecondsTZ, "Z070000"}, {NumSecondsTZ, "-070000"}},
+			{{ISO8601ColonSecondsTZ, "Z07:00:00"}, {NumColonSecondsTZ, "-07:00:00"}},
+		}
+		if n > len(tokens) {
+			break
+		}
+		token := tokens[n-1][strings.IndexByte("Xx", c)]
+		switch n {
+		case 1:
+			return token, "Go drops non-zero offset minutes"
+		case 4, 5:
+			return token, "Go always prints offset seconds"
+		}
+		return token, ""
+	case 'Z':
+		switch n {
+		case 1, 2, 3:
+			return Token{NumTZ, "-0700"}, ""
+		case 4:
+			return Token{NumColonTZ, "-07:00"}, "no layout token for localized offsets"
+		case 5:
+			return Token{ISO8601ColonTZ, "Z07:00"}, "Go drops offset seconds"
+		}
+	case 'O':
+		return Token{NumColonTZ, "-07:00"}, "no layout token for localized offsets"
+	case 'z':
+		if n <= 3 {
+			return Token{TZ, "MST"}, ""
+		}
+		return Token{TZ, "MST"}, "no layout token for full zone names"
+	case 'V':
+		return Token{TZ, "MST"}, "no layout token for zone IDs"
+	case 'v':
+		return Token{TZ, "MST"}, "no layout token for generic zone names"
+	}
+	return Token{}, "no layout token for this field"
+}
+
+// FromJava translates a java.time DateTimeFormatter pattern into a layout.
+// Quoted text is copied, optional sections are always formatted, and
+// fields with no exact counterpart are reported and translated to the
+// closest token, or kept as literal text.
+func FromJava(pattern string) (string, []Diagnostic) {
+	return fromPattern(pattern, javaFlavor)
+}
+
+// fromPattern translates a pattern of flavor f. Only Java has optional
+// sections and the pad modifier, only date-fns has ordinals.
+func fromPattern(pattern string, f flavor) (string, []Diagnostic) {
+	var b builder
+	pad, padAt := 0, 0
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if pad > 0 && !isJavaLetter(c) {
+			b.lossy(padAt, pattern[padAt:i], "pad modifier without a field")
+			pad = 0
+		}
+		switch {
+		case strings.HasPrefix(pattern[i:], "''"):
+			b.literal(i, "'")
+			i += 2
+		case c == '\'':
+			j := i + 1
+			var text strings.Builder
+			for {
+				k := strings.IndexByte(pattern[j:], '\'')
+				if k < 0 {
+					text.WriteString(pattern[j:])
+					b.lossy(i, pattern[i:], "unterminated quote")
+					j = len(pattern)
+					break
+				}
+				text.WriteString(pattern[j : j+k])
+				j += k + 1
+				if j < len(pattern) && pattern[j] == '\'' {
+					text.WriteByte('\'')
+					j++
+					continue
+				}
+				break
+			}
+			b.literal(i, text.String())
+			i = j
+		case f != javaFlavor && !isJavaLetter(c):
+			b.literal(i, pattern[i:i+1])
+			i++
+		case c == '[':
+			b.lossy(i, "[", "Go layouts have no optional sections, the section is always formatted")
+			i++
+		case c == ']':
+			i++
+		case c == '{' || c == '}' || c == '#':
+			b.literal(i, pattern[i:i+1])
+			b.lossy(i, pattern[i:i+1], "reserved pattern character")
+			i++
+		case isJavaLetter(c):
+			j := i + 1
+			for j < len(pattern) && pattern[j] == c {
+				j++
+			}
+			text, n := pattern[i:j], j-i
+			if c == 'p' && f == javaFlavor {
+				pad, padAt = n, i
+				i = j
+				continue
+			}
+			if c == 'S' {
+				javaFraction(&b, i, text)
+				i = j
+				continue
+			}
+			if f == dateFnsFlavor && j < len(pattern) && pattern[j] == 'o' {
+				if text += "o"; text == "do" {
+					b.token(i, OrdinalDay, "{2nd}")
+				} else {
+					b.literal(i, text)
+					b.lossy(i, text, "no layout token for this ordinal")
+				}
+				i = j + 1
+				continue
+			}
+			token, message := javaField(c, n, f)
+			if pad > 0 {
+				if padded, ok := javaPadded[pattern[padAt:j]]; ok {
+					token, message = padded, ""
+				} else {
+					b.lossy(padAt, pattern[padAt:j], "no layout token with this padding")
+				}
+				pad = 0
+			}
+			if message != "" {
+				b.lossy(i, text, message)
+			}
+			if token.Text == "" {
+				b.literal(i, text)
+			} else {
+				b.token(i, token.Kind, token.Text)
+			}
+			i = j
+		default:
+			b.literal(i, pattern[i:i+1])
+			i++
+		}
+	}
+	if pad > 0 {
+		b.lossy(padAt, pattern[padAt:], "pad modifier without a field")
+	}
+	return b.layout()
+}
+
+// javaFraction adds the fraction of a run of S letters. A '.' or ','
+// literal right before it becomes the separator of a FracSecond token.
+func javaFraction(b *builder, offset int, text string) {
+	n := len(text)
+	if n > 9 {
+		b.lossy(offset, text, "Go has at most nine fraction digits")
+		n = 9
+	}
+	if b.fraction(offset, FracSecond0, n) != 0 {
+		return
+	}
+	switch {
+	case n <= 3:
+		b.token(offset, Milliseconds, "{frac3}")
+	case n <= 6:
+		b.token(offset, Microseconds, "{frac6}")
+	default:
+		b.token(offset, Nanoseconds, "{frac9}")
+	}
+	if n != 3 && n != 6 && n != 9 {
+		b.lossy(offset, text, "no layout token for this many fraction digits without a separator")
+	}
+}
+
+// isJavaLetter reports whether c is reserved as a pattern letter.
+func isJavaLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// ToJava translates a layout into a java.time DateTimeFormatter pattern.
+// Tokens with no exact counterpart are reported and translated to the
+// closest pattern letters.
+func ToJava(layout string) (string, []Diagnostic) {
+	return toPattern(layout, javaFlavor)
+}
+
+// toPattern translates a layout into a pattern of flavor f.
+func toPattern(layout string, f flavor) (string, []Diagnostic) {
+	var out strings.Builder
+	var diags []Diagnostic
+	lossy := func(offset int, token Token, message string) {
+		diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: message})
+	}
+	offset := 0
+	for _, token := range Tokenize(layout) {
+		if spelled, ok := dateFnsTokens[token.Kind]; ok && f == dateFnsFlavor {
+			out.WriteString(spelled)
+			offset += len(token.Text)
+			continue
+		}
+		switch token.Kind {
+		case Literal:
+			out.WriteString(quoteJava(token.Text, f))
+		case LongYear:
+			out.WriteString("yyyy")
+		case Year:
+			out.WriteString("yy")
+		case LongMonth:
+			out.WriteString("MMMM")
+		case Month:
+			out.WriteString("MMM")
+		case NumMonth:
+			out.WriteString("M")
+		case ZeroMonth:
+			out.WriteString("MM")
+		case LongWeekDay:
+			out.WriteString("EEEE")
+		case WeekDay:
+			out.WriteString("EEE")
+		case Day:
+			out.WriteString("d")
+		case UnderDay, UnderYearDay, UnderHour, UnderHour12:
+			padded := map[Kind]string{UnderDay: "ppd", UnderYearDay: "pppD", UnderHour: "ppH", UnderHour12: "pph"}[token.Kind]
+			if f != javaFlavor {
+				padded = strings.TrimLeft(padded, "p")
+				lossy(offset, token, "only Java has the pad modifier")
+			}
+			out.WriteString(padded)
+		case ZeroDay:
+			out.WriteString("dd")
+		case OrdinalDay:
+			out.WriteString("d")
+			lossy(offset, token, "no pattern letter for ordinal days")
+		case ZeroYearDay:
+			out.WriteString("DDD")
+		case Hour:
+			out.WriteString("HH")
+		case Hour12:
+			out.WriteString("h")
+		case ZeroHour12:
+			out.WriteString("hh")
+		case Minute:
+			out.WriteString("m")
+		case ZeroMinute:
+			out.WriteString("mm")
+		case Second:
+			out.WriteString("s")
+		case ZeroSecond:
+			out.WriteString("ss")
+		case PM:
+			out.WriteString("a")
+		case LowerPM:
+			out.WriteString("a")
+			lossy(offset, token, "no pattern letter for lower-case AM/PM")
+		case TZ:
+			out.WriteString("z")
+			if f == dateFnsFlavor {
+				lossy(offset, token, "date-fns formats z as a GMT offset")
+			}
+		case ISO8601TZ:
+			out.WriteString("XX")
+		case ISO8601ColonTZ:
+			out.WriteString("XXX")
+		case NumTZ:
+			out.WriteString("xx")
+		case NumColonTZ:
+			out.WriteString("xxx")
+		case ISO8601ShortTZ:
+			out.WriteString("X")
+			lossy(offset, token, "patterns print non-zero offset minutes")
+		case NumShortTZ:
+			out.WriteString("x")
+			lossy(offset, token, "patterns print non-zero offset minutes")
+		case ISO8601SecondsTZ:
+			out.WriteString("XXXX")
+			lossy(offset, token, "patterns print offset seconds only when non-zero")
+		case ISO8601ColonSecondsTZ:
+			out.WriteString("XXXXX")
+			lossy(offset, token, "patterns print offset seconds only when non-zero")
+		case NumSecondsTZ:
+			out.WriteString("xxxx")
+			lossy(offset, token, "patterns print offset seconds only when non-zero")
+		case NumColonSecondsTZ:
+			out.WriteString("xxxxx")
+			lossy(offset, token, "patterns print offset seconds only when non-zero")
+		case FracSecond0, FracSecond9:
+			out.WriteByte(token.Separator())
+			out.WriteString(strings.Repeat("S", token.Digits()))
+			if token.Kind == FracSecond9 {
+				lossy(offset, token, "patterns cannot omit trailing zeros")
+			}
+		case Milliseconds:
+			out.WriteString("SSS")
+		case Microseconds:
+			out.WriteString("SSSSSS")
+		case Nanoseconds:
+			out.WriteString("SSSSSSSSS")
+		case ISOYear:
+			out.WriteString("YYYY")
+			lossy(offset, token, javaWeeks)
+		case ShortISOYear:
+			out.WriteString("YY")
+			lossy(offset, token, javaWeeks)
+		case ISOWeek:
+			out.WriteString("ww")
+			lossy(offset, token, javaWeeks)
+		case ISOWeekDay:
+			out.WriteString("e")
+			lossy(offset, token, javaWeeks)
+		default:
+			out.WriteString(quoteJava(token.Text, f))
+			lossy(offset, token, "no pattern letter")
+		}
+		offset += len(token.Text)
+	}
+	return out.String(), diags
+}
+
+// quoteJava quotes text that has pattern letters or reserved characters.
+// ICU and date-fns reserve no characters but letters and quotes.
+func quoteJava(text string, f flavor) string {
+	if text == "'" {
+		return "''"
+	}
+	reserved := "'[]{}#"
+	if f != javaFlavor {
+		reserved = "'"
+	}
+	for i := 0; i < len(text); i++ {
+		if isJavaLetter(text[i]) || strings.IndexByte(reserved, text[i]) >= 0 {
+			return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+		}
+	}
+	return text
+}
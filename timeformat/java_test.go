@@ -0,0 +1,66 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromJava(t *testing.T) {
+	testData := []struct {
+		Java   string
+		Layout string
+		Lossy  bool
+	}{
+		{Java: "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", Layout: "2006-01-02T15:04:05.000Z07:00"},
+		{Java: "uuuu-MM-dd HH:mm:ss,SSSSSS xx", Layout: "2006-01-02 15:04:05,000000 -0700"},
+		{Java: "EEE, dd MMM yyyy HH:mm:ss Z", Layout: time.RFC1123Z},
+		{Java: "EEEE, d MMMM yy h:mm a z", Layout: "Monday, 2 January 06 3:04 PM MST"},
+		{Java: "'o''clock' hh ''", Layout: "o'clock 03 '"},
+		{Java: "ppd ppH:mm pph pppD DDD", Layout: "_2 {_15}:04 {_3} __2 002"},
+		{Java: "HHmmssSSS", Layout: "150405{frac3}"},
+		{Java: "xxx XXXXX", Layout: "-07:00 Z07:00:00", Lossy: true},
+		{Java: "YYYY-'W'ww-e", Layout: "{isoyear}-W{isoweek}-{weekday}", Lossy: true},
+		{Java: "yyyy-MM-dd[ HH:mm]", Layout: "2006-01-02 15:04", Lossy: true},
+		{Java: "H:mm", Layout: "15:04", Lossy: true},
+		{Java: "y", Layout: "2006", Lossy: true},
+		{Java: "MMMMM", Layout: "Jan", Lossy: true},
+		{Java: "X", Layout: "Z07", Lossy: true},
+		{Java: "O", Layout: "-07:00", Lossy: true},
+		{Java: "VV", Layout: "MST", Lossy: true},
+		{Java: "G yyyy", Layout: "G 2006", Lossy: true},
+		{Java: "HH:mm:ssSSSS", Layout: "15:04:05{frac6}", Lossy: true},
+		{Java: "'at", Layout: "at", Lossy: true},
+		{Java: "'day 1' yyyy", Layout: "day 1 2006", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromJava(test.Java)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromJava %q\nwant=%q lossy=%v\ngot= %q %v", test.Java, test.Layout, test.Lossy, layout, diags)
+		}
+	}
+}
+
+func TestToJava(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Java   string
+		Lossy  bool
+	}{
+		{Layout: "2006-01-02T15:04:05.000Z07:00", Java: "yyyy-MM-dd'T'HH:mm:ss.SSSXXX"},
+		{Layout: time.RFC1123Z, Java: "EEE, dd MMM yyyy HH:mm:ss xx"},
+		{Layout: time.Kitchen, Java: "h:mma"},
+		{Layout: "2 Jan 06 at 15:04 MST", Java: "d MMM yy' at 'HH:mm z"},
+		{Layout: "_2 {_15} {_3} __2 {frac6}", Java: "ppd ppH pph pppD SSSSSS"},
+		{Layout: "15:04 o'clock", Java: "HH:mm' o''clock'"},
+		{Layout: time.RFC3339Nano, Java: "yyyy-MM-dd'T'HH:mm:ss.SSSSSSSSSXXX", Lossy: true},
+		{Layout: "{isoyear}-W{isoweek}", Java: "YYYY'-W'ww", Lossy: true},
+		{Layout: "3:04pm", Java: "h:mma", Lossy: true},
+		{Layout: "{unix}", Java: "'{unix}'", Lossy: true},
+	}
+	for _, test := range testData {
+		pattern, diags := ToJava(test.Layout)
+		if pattern != test.Java || (len(diags) > 0) != test.Lossy {
+			t.Errorf("ToJava %q\nwant=%q lossy=%v\ngot= %q %v", test.Layout, test.Java, test.Lossy, pattern, diags)
+		}
+	}
+}
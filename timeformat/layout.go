@@ -0,0 +1,310 @@
+package timeformat
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout is a layout compiled for repeated formatting. Go layout tokens
+// format exactly as time.Format does; extended tokens and names follow the
+// layout's locale.
+type Layout struct {
+	layout string
+	tokens []Token
+	locale *Locale
+}
+
+// Compile tokenizes layout for use with Format. The layout formats in
+// English until WithLocale says otherwise.
+func Compile(layout string) *Layout {
+	return &Layout{layout: layout, tokens: Tokenize(layout), locale: English}
+}
+
+// Format is like time.Time.Format but also understands extended tokens.
+func Format(t time.Time, layout string) string {
+	return Compile(layout).Format(t)
+}
+
+// WithLocale returns a copy of l that formats names and ordinals in locale.
+func (l *Layout) WithLocale(locale *Locale) *Layout {
+	c := *l
+	c.locale = locale
+	return &c
+}
+
+// Locale returns the locale l formats in.
+func (l *Layout) Locale() *Locale {
+	return l.locale
+}
+
+// String returns the source layout.
+func (l *Layout) String() string {
+	return l.layout
+}
+
+// Tokens returns the tokens of the layout. The result must not be modified.
+func (l *Layout) Tokens() []Token {
+	return l.tokens
+}
+
+// Format returns t formatted with the layout.
+func (l *Layout) Format(t time.Time) string {
+	return string(l.AppendFormat(make([]byte, 0, len(l.layout)+10), t))
+}
+
+// AppendFormat is like Format but appends the result to b.
+func (l *Layout) AppendFormat(b []byte, t time.Time) []byte {
+	var (
+		year   = -1
+		month  time.Month
+		day    int
+		yday   = -1
+		hour   = -1
+		min    int
+		sec    int
+		name   string
+		offset int
+		zoned  bool
+	)
+	for _, token := range l.tokens {
+		switch token.Kind {
+		case Literal:
+			b = append(b, token.Text...)
+			continue
+		case LongYear, Year, LongMonth, Month, NumMonth, ZeroMonth, Day, UnderDay, ZeroDay, OrdinalDay, Century:
+			if year < 0 {
+				year, month, day = t.Date()
+			}
+		case UnderYearDay, ZeroYearDay, SundayWeek, MondayWeek:
+			if yday < 0 {
+				yday = t.YearDay()
+			}
+		case Hour, Hour12, ZeroHour12, UnderHour, UnderHour12, Minute, ZeroMinute, Second, ZeroSecond, PM, LowerPM:
+			if hour < 0 {
+				hour, min, sec = t.Clock()
+			}
+		case TZ, ISO8601TZ, ISO8601SecondsTZ, ISO8601ShortTZ, ISO8601ColonTZ, ISO8601ColonSecondsTZ,
+			NumTZ, NumSecondsTZ, NumShortTZ, NumColonTZ, NumColonSecondsTZ:
+			if !zoned {
+				name, offset = t.Zone()
+				zoned = true
+			}
+		}
+
+		switch token.Kind {
+		case Year:
+			y := year
+			if y < 0 {
+				y = -y
+			}
+			b = appendInt(b, y%100, 2)
+		case LongYear:
+			b = appendInt(b, year, 4)
+		case Month:
+			b = append(b, l.locale.ShortMonths[month-1]...)
+		case LongMonth:
+			b = append(b, l.locale.Months[month-1]...)
+		case NumMonth:
+			b = appendInt(b, int(month), 0)
+		case ZeroMonth:
+			b = appendInt(b, int(month), 2)
+		case WeekDay:
+			b = append(b, l.locale.ShortDays[t.Weekday()]...)
+		case LongWeekDay:
+			b = append(b, l.locale.Days[t.Weekday()]...)
+		case Day:
+			b = appendInt(b, day, 0)
+		case UnderDay:
+			if day < 10 {
+				b = append(b, ' ')
+			}
+			b = appendInt(b, day, 0)
+		case ZeroDay:
+			b = appendInt(b, day, 2)
+		case OrdinalDay:
+			b = append(b, l.locale.Ordinal(day)...)
+		case UnderYearDay:
+			if yday < 100 {
+				b = append(b, ' ')
+				if yday < 10 {
+					b = append(b, ' ')
+				}
+			}
+			b = appendInt(b, yday, 0)
+		case ZeroYearDay:
+			b = appendInt(b, yday, 3)
+		case Hour:
+			b = appendInt(b, hour, 2)
+		case UnderHour:
+			if hour < 10 {
+				b = append(b, ' ')
+			}
+			b = appendInt(b, hour, 0)
+		case Hour12, ZeroHour12, UnderHour12:
+			// noon is 12PM, midnight is 12AM
+			hr := hour % 12
+			if hr == 0 {
+				hr = 12
+			}
+			switch token.Kind {
+			case Hour12:
+				b = appendInt(b, hr, 0)
+			case UnderHour12:
+				if hr < 10 {
+					b = append(b, ' ')
+				}
+				b = appendInt(b, hr, 0)
+			default:
+				b = appendInt(b, hr, 2)
+			}
+		case Minute:
+			b = appendInt(b, min, 0)
+		case ZeroMinute:
+			b = appendInt(b, min, 2)
+		case Second:
+			b = appendInt(b, sec, 0)
+		case ZeroSecond:
+			b = appendInt(b, sec, 2)
+		case PM, LowerPM:
+			marker := l.locale.AM
+			if hour >= 12 {
+				marker = l.locale.PM
+			}
+			if token.Kind == LowerPM {
+				marker = strings.ToLower(marker)
+			}
+			b = append(b, marker...)
+		case ISO8601TZ, ISO8601SecondsTZ, ISO8601ShortTZ, ISO8601ColonTZ, ISO8601ColonSecondsTZ,
+			NumTZ, NumSecondsTZ, NumShortTZ, NumColonTZ, NumColonSecondsTZ:
+			b = appendOffset(b, offset, token.Kind)
+		case TZ:
+			if name != "" {
+				b = append(b, name...)
+				break
+			}
+			// no zone name known, use the -0700 form
+			b = appendOffset(b, offset, NumTZ)
+		case FracSecond0, FracSecond9:
+			b = appendNano(b, t.Nanosecond(), token)
+		case Milliseconds:
+			b = appendInt(b, t.Nanosecond()/1e6, 3)
+		case Microseconds:
+			b = appendInt(b, t.Nanosecond()/1e3, 6)
+		case Nanoseconds:
+			b = appendInt(b, t.Nanosecond(), 9)
+		case Century:
+			b = appendInt(b, year/100, 2)
+		case ISOYear, ShortISOYear:
+			y, _ := t.ISOWeek()
+			if token.Kind == ShortISOYear {
+				b = appendInt(b, y%100, 2)
+			} else {
+				b = appendInt(b, y, 4)
+			}
+		case ISOWeek:
+			_, w := t.ISOWeek()
+			b = appendInt(b, w, 2)
+		case ISOWeekDay:
+			wd := int(t.Weekday())
+			if wd == 0 {
+				wd = 7
+			}
+			b = appendInt(b, wd, 0)
+		case WeekDayNum:
+			b = appendInt(b, int(t.Weekday()), 0)
+		case SundayWeek:
+			b = appendInt(b, (yday+6-int(t.Weekday()))/7, 2)
+		case MondayWeek:
+			b = appendInt(b, (yday+6-(int(t.Weekday())+6)%7)/7, 2)
+		case Unix:
+			b = strconv.AppendInt(b, t.Unix(), 10)
+		}
+	}
+	return b
+}
+
+// appendOffset appends a zone offset in seconds in the form of kind.
+func appendOffset(b []byte, offset int, kind Kind) []byte {
+	// the Z variants print Z for UTC
+	if offset == 0 && kind >= ISO8601TZ && kind <= ISO8601ColonSecondsTZ {
+		return append(b, 'Z')
+	}
+	zone := offset / 60 // minutes
+	abs := offset
+	if zone < 0 {
+		b = append(b, '-')
+		zone = -zone
+		abs = -abs
+	} else {
+		b = append(b, '+')
+	}
+	b = appendInt(b, zone/60, 2)
+	colon := kind == ISO8601ColonTZ || kind == NumColonTZ || kind == ISO8601ColonSecondsTZ || kind == NumColonSecondsTZ
+	if colon {
+		b = append(b, ':')
+	}
+	if kind != NumShortTZ && kind != ISO8601ShortTZ {
+		b = appendInt(b, zone%60, 2)
+	}
+	if kind == ISO8601SecondsTZ || kind == NumSecondsTZ || kind == ISO8601ColonSecondsTZ || kind == NumColonSecondsTZ {
+		if colon {
+			b = append(b, ':')
+		}
+		b = appendInt(b, abs%60, 2)
+	}
+	return b
+}
+
+// appendNano appends the fraction of a second for a FracSecond token.
+func appendNano(b []byte, nanosec int, token Token) []byte {
+	trim := token.Kind == FracSecond9
+	n := token.Digits()
+	if trim && (n == 0 || nanosec == 0) {
+		return b
+	}
+	dot := token.Separator()
+	b = append(b, dot)
+	b = appendInt(b, nanosec, 9)
+	if n < 9 {
+		b = b[:len(b)-9+n]
+	}
+	if trim {
+		for len(b) > 0 && b[len(b)-1] == '0' {
+			b = b[:len(b)-1]
+		}
+		if len(b) > 0 && b[len(b)-1] == dot {
+			b = b[:len(b)-1]
+		}
+	}
+	return b
+}
+
+// appendInt appends the decimal form of x, padded with zeros to width.
+func appendInt(b []byte, x int, width int) []byte {
+	if 0 <= x && x < 100 && width <= 2 {
+		// the common case of two-digit fields
+		if x >= 10 || width == 2 {
+			b = append(b, byte('0'+x/10))
+		}
+		return append(b, byte('0'+x%10))
+	}
+	u := uint(x)
+	if x < 0 {
+		b = append(b, '-')
+		u = uint(-x)
+	}
+	var buf [20]byte
+	i := len(buf)
+	for u >= 10 {
+		i--
+		buf[i] = byte('0' + u%10)
+		u /= 10
+	}
+	i--
+	buf[i] = byte('0' + u)
+	for w := len(buf) - i; w < width; w++ {
+		b = append(b, '0')
+	}
+	return append(b, buf[i:]...)
+}
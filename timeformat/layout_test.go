@@ -0,0 +1,107 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatOrdinal(t *testing.T) {
+	testData := []struct {
+		Timestamp time.Time
+		Locale    *Locale
+		Layout    string
+		Expected  string
+	}{
+		{
+			Timestamp: time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+			Locale:    English,
+			Layout:    "January {2nd}, 2006",
+			Expected:  "December 24th, 2021",
+		},
+		{
+			Timestamp: time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC),
+			Locale:    English,
+			Layout:    "{2nd}",
+			Expected:  "1st",
+		},
+		{
+			Timestamp: time.Date(2021, 12, 2, 0, 0, 0, 0, time.UTC),
+			Locale:    English,
+			Layout:    "{2nd}",
+			Expected:  "2nd",
+		},
+		{
+			Timestamp: time.Date(2021, 12, 3, 0, 0, 0, 0, time.UTC),
+			Locale:    English,
+			Layout:    "{2nd}",
+			Expected:  "3rd",
+		},
+		{
+			Timestamp: time.Date(2021, 12, 11, 0, 0, 0, 0, time.UTC),
+			Locale:    English,
+			Layout:    "{2nd} {2nd}",
+			Expected:  "11th 11th",
+		},
+		{
+			Timestamp: time.Date(2021, 12, 12, 0, 0, 0, 0, time.UTC),
+			Locale:    English,
+			Layout:    "{2nd}",
+			Expected:  "12th",
+		},
+		{
+			Timestamp: time.Date(2021, 12, 13, 0, 0, 0, 0, time.UTC),
+			Locale:    English,
+			Layout:    "{2nd}",
+			Expected:  "13th",
+		},
+		{
+			Timestamp: time.Date(2021, 12, 22, 0, 0, 0, 0, time.UTC),
+			Locale:    English,
+			Layout:    "{2nd}",
+			Expected:  "22nd",
+		},
+		{
+			Timestamp: time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC),
+			Locale:    English,
+			Layout:    "{2nd}",
+			Expected:  "31st",
+		},
+		{
+			Timestamp: time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+			Locale:    German,
+			Layout:    "Monday, {2nd} January 2006",
+			Expected:  "Freitag, 24. Dezember 2021",
+		},
+		{
+			Timestamp: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			Locale:    French,
+			Layout:    "Mon {2nd} Jan 2006",
+			Expected:  "lun 1er mars 2021",
+		},
+		{
+			Timestamp: time.Date(2021, 3, 2, 15, 0, 0, 0, time.UTC),
+			Locale:    French,
+			Layout:    "{2nd} January 3 pm",
+			Expected:  "2 mars 3 pm",
+		},
+		// unknown braces are literal text
+		{
+			Timestamp: time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+			Locale:    English,
+			Layout:    "{x} {2nd",
+			Expected:  "{x} {24nd",
+		},
+	}
+	for _, test := range testData {
+		actual := Compile(test.Layout).WithLocale(test.Locale).Format(test.Timestamp)
+		if actual != test.Expected {
+			t.Errorf("\n%-10s %v\n%-10s %s\n%-10s %s\n%-10s %s\n%-10s %s",
+				"time", test.Timestamp,
+				"locale", test.Locale.Tag,
+				"template", test.Layout,
+				"got", actual,
+				"want", test.Expected,
+			)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package timeformat
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// foreign matches text that suggests a pattern written for another dialect.
+var foreign = regexp.MustCompile(`%[-_0^#]*[0-9]*:*[a-zA-Z+]|\b(?:YYYY|yyyy|YY|yy|MM|DD|dd|HH|hh|mm|ss|SSS)\b`)
+
+// Lint reports constructs in layout that are valid but probably not what the
+// author meant.
+func Lint(layout string) []Diagnostic {
+	var diags []Diagnostic
+	report := func(offset int, text, message string) {
+		diags = append(diags, Diagnostic{Offset: offset, Text: text, Message: message})
+	}
+
+	tokens := Tokenize(layout)
+	offsets := make([]int, len(tokens))
+	has := map[Kind]bool{}
+	offset := 0
+	for i, token := range tokens {
+		offsets[i] = offset
+		offset += len(token.Text)
+		has[token.Kind] = true
+	}
+	hasAny := func(kinds ...Kind) bool {
+		for _, k := range kinds {
+			if has[k] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, token := range tokens {
+		if token.Kind != Literal {
+			continue
+		}
+		if m := foreign.FindStringIndex(token.Text); m != nil {
+			text := token.Text[m[0]:m[1]]
+			message := strconv.Quote(text) + " is literal text in a Go layout; the pattern looks like Java or moment"
+			if text[0] == '%' {
+				message = strconv.Quote(text) + " is literal text in a Go layout; the pattern looks like strftime"
+			}
+			report(offsets[i]+m[0], text, message)
+		}
+	}
+	if layout != "" && (len(tokens) == 1 && tokens[0].Kind == Literal) {
+		report(0, layout, "layout has no tokens and always formats as itself")
+	}
+
+	hour12 := hasAny(Hour12, ZeroHour12, UnderHour12)
+	hour24 := hasAny(Hour, UnderHour)
+	pm := hasAny(PM, LowerPM)
+	month := hasAny(LongMonth, Month, NumMonth, ZeroMonth)
+	for i, token := range tokens {
+		at := offsets[i]
+		switch token.Kind {
+		case Hour12, ZeroHour12, UnderHour12:
+			if !pm {
+				report(at, token.Text, "12-hour clock without AM/PM")
+			}
+		case PM, LowerPM:
+			if hour24 && !hour12 {
+				report(at, token.Text, "AM/PM with a 24-hour clock")
+			}
+		case Year:
+			report(at, token.Text, "two-digit year, parsing maps 69-99 to 19xx and 00-68 to 20xx")
+		case Day, UnderDay, ZeroDay, OrdinalDay:
+			if !month {
+				report(at, token.Text, "day of month without a month")
+			}
+		case NumMonth, ZeroMonth:
+			// "1:04" or "01:04" meant as an hour
+			if i+2 < len(tokens) && tokens[i+1].Text == ":" && (tokens[i+2].Kind == Minute || tokens[i+2].Kind == ZeroMinute) {
+				report(at, token.Text, token.Text+" is the month, the hour is 15, 3 or 03")
+			}
+		case Minute, ZeroMinute:
+			if !hour12 && !hour24 && hasAny(LongYear, Year) {
+				report(at, token.Text, token.Text+" is the minute, the month is 01, 1 or Jan")
+			}
+		case FracSecond0, FracSecond9:
+			if i == 0 || tokens[i-1].Kind != Second && tokens[i-1].Kind != ZeroSecond {
+				report(at, token.Text, "fraction of a second not following the seconds")
+			}
+		case TZ:
+			report(at, token.Text, "zone abbreviations are ambiguous when parsing, consider Z07:00 or -07:00")
+		case Century, ISOYear, ShortISOYear, ISOWeek, SundayWeek, MondayWeek:
+			report(at, token.Text, "token can only be formatted, parsing fails")
+		}
+	}
+	return diags
+}
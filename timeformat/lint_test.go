@@ -0,0 +1,44 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLint(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Want   []string // the text of each diagnostic
+	}{
+		{Layout: time.RFC3339Nano},
+		{Layout: time.Kitchen},
+		{Layout: "yyyy-MM-dd", Want: []string{"yyyy", "yyyy-MM-dd"}},
+		{Layout: "%Y-%m-%d 15:04", Want: []string{"%Y"}},
+		{Layout: "2006-01-02 03:04", Want: []string{"03"}},
+		{Layout: "15:04 PM", Want: []string{"PM"}},
+		{Layout: "Jan 02, 06", Want: []string{"06"}},
+		{Layout: "2006 02", Want: []string{"02"}},
+		{Layout: "2006-01-02 1:04", Want: []string{"1", "04"}},
+		{Layout: "2006-04-02", Want: []string{"04", "02"}},
+		{Layout: "2006-01-02 .000", Want: []string{".000"}},
+		{Layout: "2006-01-02 15:04 MST", Want: []string{"MST"}},
+		{Layout: "{isoyear}-W{isoweek}", Want: []string{"{isoyear}", "{isoweek}"}},
+	}
+	for _, test := range testData {
+		diags := Lint(test.Layout)
+		var got []string
+		for _, d := range diags {
+			got = append(got, d.Text)
+		}
+		if len(got) != len(test.Want) {
+			t.Errorf("Lint %q\nwant=%q\ngot= %v", test.Layout, test.Want, diags)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.Want[i] {
+				t.Errorf("Lint %q\nwant=%q\ngot= %v", test.Layout, test.Want, diags)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package timeformat
+
+import "strconv"
+
+// Locale holds the words and conventions used for names, AM/PM markers and
+// ordinals.
+type Locale struct {
+	Tag         string     // BCP 47 language tag, for example "en"
+	Months      [12]string // January first
+	ShortMonths [12]string
+	Days        [7]string // Sunday first
+	ShortDays   [7]string
+	AM, PM      string
+	// Ordinal renders a day of the month as an ordinal, for example "24th".
+	// The result always starts with the day in decimal.
+	Ordinal func(day int) string
+}
+
+// English is the locale the time package uses.
+var English = &Locale{
+	Tag: "en",
+	Months: [12]string{"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"},
+	ShortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	Days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	ShortDays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	AM:          "AM",
+	PM:          "PM",
+	Ordinal: func(day int) string {
+		suffix := "th"
+		switch day % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+		if day%100/10 == 1 { // 11th, 12th, 13th
+			suffix = "th"
+		}
+		return strconv.Itoa(day) + suffix
+	},
+}
+
+// German writes ordinals with a trailing period, "24.".
+var German = &Locale{
+	Tag: "de",
+	Months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+		"Juli", "August", "September", "Oktober", "November", "Dezember"},
+	ShortMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	Days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	ShortDays:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	AM:          "AM",
+	PM:          "PM",
+	Ordinal: func(day int) string {
+		return strconv.Itoa(day) + "."
+	},
+}
+
+// French marks only the first day of the month, "1er", and writes the other
+// days as plain numbers.
+var French = &Locale{
+	Tag: "fr",
+	Months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin",
+		"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	ShortMonths: [12]string{"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+	Days:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	ShortDays:   [7]string{"dim", "lun", "mar", "mer", "jeu", "ven", "sam"},
+	AM:          "AM",
+	PM:          "PM",
+	Ordinal: func(day int) string {
+		if day == 1 {
+			return "1er"
+		}
+		return strconv.Itoa(day)
+	},
+}
+
+var locales = map[string]*Locale{
+	English.Tag: English,
+	German.Tag:  German,
+	French.Tag:  French,
+}
+
+// LookupLocale returns the locale with the given tag, or nil.
+func LookupLocale(tag string) *Locale {
+	return locales[tag]
+}
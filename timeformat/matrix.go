@@ -0,0 +1,231 @@
+package timeformat
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Column is a column of a compatibility matrix: a dialect for spelling the
+// tokens and a backend for checking them. Without a dialect the tokens are
+// spelled as layouts of this package, and without a backend the cells show
+// the translation only.
+type Column struct {
+	Name    string
+	Dialect string
+	Backend string
+}
+
+// Cell tells how a column handles one token.
+type Cell struct {
+	Token   string // spelling in the dialect, empty if there is none
+	Checked bool   // Format and Parse were checked with a backend
+	Format  bool   // the backend formats the token like this package
+	Parse   bool   // the backend parses what this package formats
+}
+
+// MatrixRow is one token and how each column handles it.
+type MatrixRow struct {
+	Layout  string // the token as a Go layout
+	Meaning string
+	Cells   []Cell
+}
+
+// Matrix is a compatibility matrix with one row per token.
+type Matrix struct {
+	Columns []Column
+	Rows    []MatrixRow
+}
+
+// matrixLayouts are the rows of a compatibility matrix, one spelling of
+// every token kind with the usual fraction widths.
+var matrixLayouts = []string{
+	"2006", "06", "January", "Jan", "1", "01", "Monday", "Mon", "2", "_2", "02", "__2", "002",
+	"15", "3", "03", "4", "04", "5", "05", "PM", "pm",
+	"MST", "Z0700", "Z070000", "Z07", "Z07:00", "Z07:00:00", "-0700", "-070000", "-07", "-07:00", "-07:00:00",
+	".000", ".000000", ".000000000", ".999", ".999999", ".999999999",
+}
+
+func init() {
+	spellings := make([]string, 0, len(extended))
+	for s := range extended {
+		spellings = append(spellings, s)
+	}
+	sort.Slice(spellings, func(i, j int) bool { return extended[spellings[i]] < extended[spellings[j]] })
+	matrixLayouts = append(matrixLayouts, spellings...)
+}
+
+// DefaultColumns returns a column for the time package, for this package,
+// for strftime checked with timefmt-go and with glibc, and for every other
+// dialect. Columns whose backend is not registered are left out.
+func DefaultColumns() []Column {
+	columns := []Column{
+		{Name: "Go", Dialect: "go", Backend: "go"},
+		{Name: "timeformat", Backend: "compiled"},
+		{Name: "strftime (timefmt-go)", Dialect: "strftime", Backend: "timefmt"},
+		{Name: "strftime (glibc)", Dialect: "strftime", Backend: "glibc"},
+	}
+	for _, name := range Dialects() {
+		if name != "go" && name != "strftime" {
+			columns = append(columns, Column{Name: name, Dialect: name, Backend: name})
+		}
+	}
+	var known []Column
+	for _, c := range columns {
+		if LookupBackend(c.Backend) == nil {
+			if c.Dialect == "" || c.Dialect == "go" || c.Dialect == "strftime" {
+				continue
+			}
+			c.Backend = ""
+		}
+		known = append(known, c)
+	}
+	return known
+}
+
+// Compatibility builds the matrix of every token against columns. A
+// backend formats a token if it gives the same text as Format on the
+// instants Differ uses, and parses it if it reads each of those texts back
+// to one that formats the same. Weekdays only need to be accepted, as
+// parsers check them without using them.
+func Compatibility(columns []Column) (Matrix, error) {
+	m := Matrix{Columns: columns}
+	samples := matrixSamples()
+	for _, layout := range matrixLayouts {
+		token := Tokenize(layout)[0]
+		row := MatrixRow{Layout: layout, Meaning: meaning(token)}
+		for _, c := range columns {
+			var cell Cell
+			if c.Dialect == "" {
+				cell.Token = layout
+			} else if d := LookupDialect(c.Dialect); d == nil {
+				return Matrix{}, fmt.Errorf("column %s: unknown dialect %q", c.Name, c.Dialect)
+			} else if spelled, diags := d.To(layout); len(diags) == 0 {
+				cell.Token = spelled
+			}
+			if c.Backend != "" {
+				b := LookupBackend(c.Backend)
+				if b == nil {
+					return Matrix{}, fmt.Errorf("column %s: unknown backend %q", c.Name, c.Backend)
+				}
+				cell.Checked = true
+				cell.Format, cell.Parse = check(b, layout, token.Kind, samples)
+				if !cell.Format && !cell.Parse {
+					cell.Token = ""
+				}
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		m.Rows = append(m.Rows, row)
+	}
+	return m, nil
+}
+
+// matrixSamples are the instants of Differ in zones with a name, since a
+// zone abbreviation cannot be parsed back from an offset.
+func matrixSamples() []time.Time {
+	var ts []time.Time
+	for _, t := range boundaries() {
+		if name, _ := t.Zone(); name != "" {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+// check reports whether b formats and parses layout on samples. Instants
+// for which b returns ErrUnsupported are skipped, and a direction is only
+// unsupported if every instant is.
+func check(b Backend, layout string, kind Kind, samples []time.Time) (format, parse bool) {
+	format, parse = true, true
+	var formatted, parsed int
+	for _, t := range samples {
+		want := Format(t, layout)
+		got, err := b.Format(t, layout)
+		if !errors.Is(err, ErrUnsupported) {
+			formatted++
+			format = format && err == nil && got == want
+		}
+		parsedTime, err := b.Parse(layout, want)
+		if errors.Is(err, ErrUnsupported) {
+			continue
+		}
+		parsed++
+		switch kind {
+		case LongWeekDay, WeekDay, ISOWeekDay, WeekDayNum:
+			parse = parse && err == nil
+		default:
+			parse = parse && err == nil && Format(parsedTime, layout) == want
+		}
+	}
+	return format && formatted > 0, parse && parsed > 0
+}
+
+// support describes a checked cell.
+func (c Cell) support() string {
+	switch {
+	case !c.Checked:
+		return "not checked"
+	case c.Format && c.Parse:
+		return "format, parse"
+	case c.Format:
+		return "format"
+	case c.Parse:
+		return "parse"
+	}
+	return ""
+}
+
+// Markdown renders the matrix as a Markdown table.
+func (m Matrix) Markdown() string {
+	var b strings.Builder
+	b.WriteString("| Go layout | Meaning |")
+	for _, c := range m.Columns {
+		b.WriteString(" " + c.Name + " |")
+	}
+	b.WriteString("\n|---|---|")
+	b.WriteString(strings.Repeat("---|", len(m.Columns)))
+	b.WriteByte('\n')
+	code := func(s string) string {
+		return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+	}
+	for _, r := range m.Rows {
+		fmt.Fprintf(&b, "| %s | %s |", code(r.Layout), r.Meaning)
+		for _, c := range r.Cells {
+			if c.Token == "" {
+				b.WriteString(" N/A |")
+			} else {
+				fmt.Fprintf(&b, " %s %s |", code(c.Token), c.support())
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// HTML renders the matrix as an HTML table.
+func (m Matrix) HTML() string {
+	var b strings.Builder
+	b.WriteString("<table>\n<tr><th>Go layout</th><th>Meaning</th>")
+	for _, c := range m.Columns {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(c.Name))
+	}
+	b.WriteString("</tr>\n")
+	for _, r := range m.Rows {
+		fmt.Fprintf(&b, "<tr><td><code>%s</code></td><td>%s</td>",
+			html.EscapeString(r.Layout), html.EscapeString(r.Meaning))
+		for _, c := range r.Cells {
+			if c.Token == "" {
+				b.WriteString("<td>N/A</td>")
+			} else {
+				fmt.Fprintf(&b, "<td><code>%s</code> %s</td>", html.EscapeString(c.Token), c.support())
+			}
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n")
+	return b.String()
+}
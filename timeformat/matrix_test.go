@@ -0,0 +1,108 @@
+package timeformat
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompatibility(t *testing.T) {
+	columns := []Column{
+		{Name: "Go", Dialect: "go", Backend: "go"},
+		{Name: "timefmt-go", Dialect: "strftime", Backend: "timefmt"},
+		{Name: "strftime", Dialect: "strftime"},
+		{Name: "layout"},
+	}
+	m, err := Compatibility(columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := map[string]MatrixRow{}
+	for _, r := range m.Rows {
+		rows[r.Layout] = r
+	}
+	testData := []struct {
+		Layout string
+		Column int
+		Want   Cell
+	}{
+		{Layout: "2006", Column: 0, Want: Cell{Token: "2006", Checked: true, Format: true, Parse: true}},
+		{Layout: "2006", Column: 1, Want: Cell{Token: "%Y", Checked: true, Format: true, Parse: true}},
+		{Layout: "2006", Column: 2, Want: Cell{Token: "%Y"}},
+		{Layout: "3", Column: 1, Want: Cell{Token: "%-I", Checked: true, Format: true}},
+		{Layout: "Z07:00", Column: 1, Want: Cell{Checked: true}},
+		{Layout: "Z07:00", Column: 2},
+		{Layout: "{unix}", Column: 0, Want: Cell{Checked: true}},
+		{Layout: "{unix}", Column: 1, Want: Cell{Token: "%s", Checked: true, Format: true}},
+		{Layout: "{unix}", Column: 3, Want: Cell{Token: "{unix}"}},
+		{Layout: "Mon", Column: 1, Want: Cell{Token: "%a", Checked: true, Format: true, Parse: true}},
+	}
+	for _, test := range testData {
+		r, ok := rows[test.Layout]
+		if !ok {
+			t.Errorf("Compatibility has no row %q", test.Layout)
+			continue
+		}
+		if got := r.Cells[test.Column]; got != test.Want {
+			t.Errorf("Compatibility %q in %s\nwant=%+v\ngot= %+v", test.Layout, columns[test.Column].Name, test.Want, got)
+		}
+	}
+	if len(m.Rows) != len(matrixLayouts) || rows["{frac9}"].Meaning == "" {
+		t.Errorf("Compatibility has %d rows, want %d with meanings", len(m.Rows), len(matrixLayouts))
+	}
+
+	if _, err := Compatibility([]Column{{Name: "x", Dialect: "cobol"}}); err == nil {
+		t.Error("Compatibility with an unknown dialect did not fail")
+	}
+}
+
+func TestMatrixRender(t *testing.T) {
+	m := Matrix{
+		Columns: []Column{{Name: "Go"}, {Name: "strftime"}},
+		Rows: []MatrixRow{
+			{Layout: "2006", Meaning: "four-digit year", Cells: []Cell{{Token: "2006", Checked: true, Format: true, Parse: true}, {Token: "%Y"}}},
+			{Layout: "{unix}", Meaning: "seconds", Cells: []Cell{{Checked: true}, {Token: "%s", Checked: true, Format: true}}},
+		},
+	}
+	md := "| Go layout | Meaning | Go | strftime |\n|---|---|---|---|\n" +
+		"| `2006` | four-digit year | `2006` format, parse | `%Y` not checked |\n" +
+		"| `{unix}` | seconds | N/A | `%s` format |\n"
+	if got := m.Markdown(); got != md {
+		t.Errorf("Markdown\nwant=%q\ngot= %q", md, got)
+	}
+	if got := m.HTML(); !strings.Contains(got, "<tr><td><code>{unix}</code></td><td>seconds</td><td>N/A</td><td><code>%s</code> format</td></tr>") {
+		t.Errorf("HTML got %q", got)
+	}
+}
+
+// oldYearsBackend is the time package, except that it does not format
+// years before 1000, as glibc does not pad them.
+type oldYearsBackend struct{ stdlibBackend }
+
+func (oldYearsBackend) Format(t time.Time, layout string) (string, error) {
+	if t.Year() < 1000 {
+		return "", ErrUnsupported
+	}
+	return t.Format(layout), nil
+}
+
+func TestCheckUnsupported(t *testing.T) {
+	samples := matrixSamples()
+	testData := []struct {
+		Backend Backend
+		Layout  string
+		Format  bool
+		Parse   bool
+	}{
+		{Backend: oldYearsBackend{}, Layout: "2006", Format: true, Parse: true},
+		{Backend: oldYearsBackend{}, Layout: "Jan", Format: true, Parse: true},
+		{Backend: stdlibBackend{}, Layout: "{isoweek}"},
+	}
+	for _, test := range testData {
+		format, parse := check(test.Backend, test.Layout, Tokenize(test.Layout)[0].Kind, samples)
+		if format != test.Format || parse != test.Parse {
+			t.Errorf("check %q: want format=%v parse=%v, got format=%v parse=%v",
+				test.Layout, test.Format, test.Parse, format, parse)
+		}
+	}
+}
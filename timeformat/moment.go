@@ -0,0 +1,366 @@
+package timeformat
+
+import "strings"
+
+// momentWeeks is the diagnostic for the locale week fields of moment.
+const momentWeeks = "moment week fields depend on the locale, translated as ISO 8601 weeks"
+
+// momentEraYear is the diagnostic for lowercase y, which date-fns reads as
+// the calendar year.
+const momentEraYear = "moment y is the year of the era, equal to the calendar year YYYY only for dates AD"
+
+// momentTokens maps moment tokens to layout tokens. Longer tokens come
+// before their prefixes, the first match wins. A zero token means there is
+// none; a message means the translation is not exact.
+var momentTokens = []struct {
+	moment  string
+	token   Token
+	message string
+}{
+	{"YYYYYY", Token{LongYear, "2006"}, "no layout token for six-digit years"},
+	{"YYYY", Token{LongYear, "2006"}, ""},
+	{"YY", Token{Year, "06"}, ""},
+	{"Y", Token{LongYear, "2006"}, "no layout token for unpadded years"},
+	{"yyyy", Token{LongYear, "2006"}, momentEraYear},
+	{"yyy", Token{LongYear, "2006"}, momentEraYear},
+	{"yy", Token{Year, "06"}, momentEraYear},
+	{"yo", Token{}, "no layout token for ordinal years"},
+	{"y", Token{LongYear, "2006"}, momentEraYear},
+	{"GGGG", Token{ISOYear, "{isoyear}"}, ""},
+	{"GG", Token{ShortISOYear, "{isoyear2}"}, ""},
+	{"gggg", Token{ISOYear, "{isoyear}"}, momentWeeks},
+	{"gg", Token{ShortISOYear, "{isoyear2}"}, momentWeeks},
+	{"Qo", Token{}, "no layout token for quarters"},
+	{"Q", Token{}, "no layout token for quarters"},
+	{"MMMM", Token{LongMonth, "January"}, ""},
+	{"MMM", Token{Month, "Jan"}, ""},
+	{"MM", Token{ZeroMonth, "01"}, ""},
+	{"Mo", Token{}, "no layout token for ordinal months"},
+	{"M", Token{NumMonth, "1"}, ""},
+	{"DDDD", Token{ZeroYearDay, "002"}, ""},
+	{"DDDo", Token{}, "no layout token for ordinal days of the year"},
+	{"DDD", Token{ZeroYearDay, "002"}, "no layout token for an unpadded day of year"},
+	{"DD", Token{ZeroDay, "02"}, ""},
+	{"Do", Token{OrdinalDay, "{2nd}"}, ""},
+	{"D", Token{Day, "2"}, ""},
+	{"dddd", Token{LongWeekDay, "Monday"}, ""},
+	{"ddd", Token{WeekDay, "Mon"}, ""},
+	{"dd", Token{WeekDay, "Mon"}, "no layout token for two-letter day names"},
+	{"do", Token{}, "no layout token for ordinal weekdays"},
+	{"d", Token{WeekDayNum, "{weekday0}"}, ""},
+	{"E", Token{ISOWeekDay, "{weekday}"}, ""},
+	{"e", Token{WeekDayNum, "{weekday0}"}, momentWeeks},
+	{"WW", Token{ISOWeek, "{isoweek}"}, ""},
+	{"Wo", Token{}, "no layout token for ordinal weeks"},
+	{"W", Token{ISOWeek, "{isoweek}"}, "no layout token for an unpadded week"},
+	{"ww", Token{ISOWeek, "{isoweek}"}, momentWeeks},
+	{"wo", Token{}, "no layout token for ordinal weeks"},
+	{"w", Token{ISOWeek, "{isoweek}"}, momentWeeks},
+	{"HH", Token{Hour, "15"}, ""},
+	{"H", Token{Hour, "15"}, "no layout token for an unpadded 24-hour clock"},
+	{"hh", Token{ZeroHour12, "03"}, ""},
+	{"h", Token{Hour12, "3"}, ""},
+	{"kk", Token{Hour, "15"}, "no layout token for the 1-24 clock"},
+	{"k", Token{Hour, "15"}, "no layout token for the 1-24 clock"},
+	{"mm", Token{ZeroMinute, "04"}, ""},
+	{"m", Token{Minute, "4"}, ""},
+	{"ss", Token{ZeroSecond, "05"}, ""},
+	{"s", Token{Second, "5"}, ""},
+	{"A", Token{PM, "PM"}, ""},
+	{"a", Token{LowerPM, "pm"}, ""},
+	{"ZZ", Token{NumTZ, "-0700"}, ""},
+	{"Z", Token{NumColonTZ, "-07:00"}, ""},
+	{"zzz", Token{TZ, "MST"}, "day.js zzz is the long zone name, moment writes zz and z"},
+	{"zz", Token{TZ, "MST"}, ""},
+	{"z", Token{TZ, "MST"}, ""},
+	{"X", Token{Unix, "{unix}"}, ""},
+	{"x", Token{}, "no layout token for millisecond timestamps"},
+	{"NNNNN", Token{}, "no layout token for eras"},
+	{"NNNN", Token{}, "no layout token for eras"},
+	{"NNN", Token{}, "no layout token for eras"},
+	{"NN", Token{}, "no layout token for eras"},
+	{"N", Token{}, "no layout token for eras"},
+}
+
+// momentLocalized holds the localized formats of moment in English.
+var momentLocalized = []struct {
+	moment, format string
+}{
+	{"LTS", "h:mm:ss A"},
+	{"LT", "h:mm A"},
+	{"LLLL", "dddd, MMMM D, YYYY h:mm A"},
+	{"LLL", "MMMM D, YYYY h:mm A"},
+	{"LL", "MMMM D, YYYY"},
+	{"L", "MM/DD/YYYY"},
+	{"llll", "ddd, MMM D, YYYY h:mm A"},
+	{"lll", "MMM D, YYYY h:mm A"},
+	{"ll", "MMM D, YYYY"},
+	{"l", "M/D/YYYY"},
+}
+
+// FromMoment translates a moment.js format into a layout. Text in brackets
+// or after a backslash is copied, localized formats are expanded in
+// English, and tokens with no exact counterpart are reported and translated
+// to the closest token, or kept as literal text.
+//
+// day.js formats share the tokens it knows with moment: YY, YYYY, M to
+// MMMM, D, DD, d to dddd, H, HH, h, hh, m, mm, s, ss, SSS, Z, ZZ, A and a,
+// with the AdvancedFormat plugin Q, Do, k, kk, X, x, w, ww, W, WW, gggg,
+// GGGG and z, and with LocalizedFormat L to LLLL and l to llll. Its zzz,
+// the long zone name, is reported. Other moment tokens, such as DDDD, E,
+// GG and y, are written literally by day.js.
+func FromMoment(format string) (string, []Diagnostic) {
+	var b builder
+	fromMoment(&b, format, -1)
+	return b.layout()
+}
+
+// fromMoment adds the tokens of format to b. Localized formats are expanded
+// with all offsets set to the position of the format in the outer one.
+func fromMoment(b *builder, format string, at int) {
+next:
+	for i := 0; i < len(format); {
+		offset := at
+		if at < 0 {
+			offset = i
+		}
+		switch c := format[i]; {
+		case c == '[':
+			if j := strings.IndexAny(format[i+1:], "[]"); j >= 0 && format[i+1+j] == ']' {
+				b.literal(offset, format[i+1:i+1+j])
+				i += j + 2
+				continue
+			}
+		case c == '\\' && i+1 < len(format):
+			b.literal(offset, format[i+1:i+2])
+			i += 2
+			continue
+		case c == 'S':
+			j := i + 1
+			for j < len(format) && format[j] == 'S' {
+				j++
+			}
+			javaFraction(b, offset, format[i:j])
+			i = j
+			continue
+		}
+		for _, l := range momentLocalized {
+			if strings.HasPrefix(format[i:], l.moment) {
+				b.lossy(offset, l.moment, "localized format, expanded in English")
+				fromMoment(b, l.format, offset)
+				i += len(l.moment)
+				continue next
+			}
+		}
+		for _, m := range momentTokens {
+			if strings.HasPrefix(format[i:], m.moment) {
+				if m.message != "" {
+					b.lossy(offset, m.moment, m.message)
+				}
+				if m.token.Text == "" {
+					b.literal(offset, m.moment)
+				} else {
+					b.token(offset, m.token.Kind, m.token.Text)
+				}
+				i += len(m.moment)
+				continue next
+			}
+		}
+		b.literal(offset, format[i:i+1])
+		i++
+	}
+}
+
+// ToMoment translates a layout into a moment.js format. Tokens with no
+// exact counterpart are reported and translated to the closest token.
+func ToMoment(layout string) (string, []Diagnostic) {
+	var out strings.Builder
+	var diags []Diagnostic
+	lossy := func(offset int, token Token, message string) {
+		diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: message})
+	}
+	offset := 0
+	for _, token := range Tokenize(layout) {
+		switch token.Kind {
+		case Literal:
+			out.WriteString(quoteMoment(token.Text))
+		case LongYear:
+			out.WriteString("YYYY")
+		case Year:
+			out.WriteString("YY")
+		case LongMonth:
+			out.WriteString("MMMM")
+		case Month:
+			out.WriteString("MMM")
+		case NumMonth:
+			out.WriteString("M")
+		case ZeroMonth:
+			out.WriteString("MM")
+		case LongWeekDay:
+			out.WriteString("dddd")
+		case WeekDay:
+			out.WriteString("ddd")
+		case Day:
+			out.WriteString("D")
+		case ZeroDay:
+			out.WriteString("DD")
+		case OrdinalDay:
+			out.WriteString("Do")
+		case ZeroYearDay:
+			out.WriteString("DDDD")
+		case Hour:
+			out.WriteString("HH")
+		case Hour12:
+			out.WriteString("h")
+		case ZeroHour12:
+			out.WriteString("hh")
+		case Minute:
+			out.WriteString("m")
+		case ZeroMinute:
+			out.WriteString("mm")
+		case Second:
+			out.WriteString("s")
+		case ZeroSecond:
+			out.WriteString("ss")
+		case PM:
+			out.WriteString("A")
+		case LowerPM:
+			out.WriteString("a")
+		case TZ:
+			out.WriteString("z")
+			lossy(offset, token, "moment formats zone names only with moment-timezone")
+		case NumTZ:
+			out.WriteString("ZZ")
+		case NumColonTZ:
+			out.WriteString("Z")
+		case ISO8601TZ, ISO8601ShortTZ, ISO8601SecondsTZ, NumShortTZ, NumSecondsTZ:
+			out.WriteString("ZZ")
+			lossy(offset, token, "moment has no such offset form, using ZZ")
+		case ISO8601ColonTZ, ISO8601ColonSecondsTZ, NumColonSecondsTZ:
+			out.WriteString("Z")
+			lossy(offset, token, "moment has no such offset form, using Z")
+		case UnderDay, UnderYearDay, UnderHour, UnderHour12:
+			out.WriteString(map[Kind]string{UnderDay: "D", UnderYearDay: "DDD", UnderHour: "H", UnderHour12: "h"}[token.Kind])
+			lossy(offset, token, "moment cannot pad with spaces")
+		case FracSecond0, FracSecond9:
+			out.WriteByte(token.Separator())
+			out.WriteString(strings.Repeat("S", token.Digits()))
+			if token.Kind == FracSecond9 {
+				lossy(offset, token, "moment cannot omit trailing zeros")
+			} else if token.Digits() > 3 {
+				lossy(offset, token, "JavaScript dates have millisecond precision")
+			}
+		case Milliseconds:
+			out.WriteString("SSS")
+		case Microseconds:
+			out.WriteString("SSSSSS")
+			lossy(offset, token, "JavaScript dates have millisecond precision")
+		case Nanoseconds:
+			out.WriteString("SSSSSSSSS")
+			lossy(offset, token, "JavaScript dates have millisecond precision")
+		case ISOYear:
+			out.WriteString("GGGG")
+		case ShortISOYear:
+			out.WriteString("GG")
+		case ISOWeek:
+			out.WriteString("WW")
+		case ISOWeekDay:
+			out.WriteString("E")
+		case WeekDayNum:
+			out.WriteString("d")
+		case Unix:
+			out.WriteString("X")
+		default:
+			out.WriteString(quoteMoment(token.Text))
+			lossy(offset, token, "no moment token")
+		}
+		offset += len(token.Text)
+	}
+	return out.String(), diags
+}
+
+// quoteMoment escapes text that has letters, brackets or backslashes, in
+// brackets if it can and with backslashes otherwise.
+func quoteMoment(text string) string {
+	if !strings.ContainsFunc(text, func(r rune) bool {
+		return r < 0x80 && isJavaLetter(byte(r)) || strings.ContainsRune(`[]\`, r)
+	}) {
+		return text
+	}
+	if !strings.ContainsAny(text, "[]") {
+		return "[" + text + "]"
+	}
+	var out strings.Builder
+	for _, r := range text {
+		if r < 0x80 && isJavaLetter(byte(r)) || strings.ContainsRune(`[]\`, r) {
+			out.WriteByte('\\')
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+// dateFnsTokens are the tokens date-fns spells differently from Java.
+var dateFnsTokens = map[Kind]string{
+	OrdinalDay: "do",
+	LowerPM:    "aaa",
+	ISOYear:    "RRRR",
+	ISOWeek:    "II",
+	ISOWeekDay: "i",
+	Unix:       "t",
+}
+
+// dateFnsField is javaField for the letters date-fns reads differently.
+func dateFnsField(c byte, n int) (Token, string, bool) {
+	switch c {
+	case 'R':
+		if n == 4 {
+			return Token{ISOYear, "{isoyear}"}, "", true
+		}
+		return Token{ISOYear, "{isoyear}"}, "no layout token for this year width", true
+	case 'I':
+		if n == 2 {
+			return Token{ISOWeek, "{isoweek}"}, "", true
+		}
+		return Token{ISOWeek, "{isoweek}"}, "no layout token for this week width", true
+	case 'i':
+		switch n {
+		case 1:
+			return Token{ISOWeekDay, "{weekday}"}, "", true
+		case 3:
+			return Token{WeekDay, "Mon"}, "", true
+		case 4:
+			return Token{LongWeekDay, "Monday"}, "", true
+		}
+		return Token{ISOWeekDay, "{weekday}"}, "no layout token for this weekday width", true
+	case 'a':
+		switch n {
+		case 1, 2:
+			return Token{PM, "PM"}, "", true
+		case 3:
+			return Token{LowerPM, "pm"}, "", true
+		}
+		return Token{PM, "PM"}, "no layout token for long or narrow day periods", true
+	case 't':
+		return Token{Unix, "{unix}"}, "", true
+	case 'T':
+		return Token{}, "no layout token for millisecond timestamps", true
+	case 'z':
+		return Token{NumColonTZ, "-07:00"}, "no layout token for localized offsets", true
+	case 'P', 'p':
+		return Token{}, "no layout token for localized formats", true
+	}
+	return Token{}, "", false
+}
+
+// FromDateFns translates a date-fns format into a layout. The letters are
+// those of Unicode patterns with ordinals such as "do" and the ISO week
+// fields R, I and i. Quoted text is copied.
+func FromDateFns(format string) (string, []Diagnostic) {
+	return fromPattern(format, dateFnsFlavor)
+}
+
+// ToDateFns translates a layout into a date-fns format.
+func ToDateFns(layout string) (string, []Diagnostic) {
+	return toPattern(layout, dateFnsFlavor)
+}
@@ -0,0 +1,94 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromMoment(t *testing.T) {
+	testData := []struct {
+		Moment string
+		Layout string
+		Lossy  bool
+	}{
+		{Moment: "YYYY-MM-DD HH:mm", Layout: "2006-01-02 15:04"},
+		{Moment: "Do MMM", Layout: "{2nd} Jan"},
+		{Moment: "dddd, MMMM D YYYY h:mm:ss a", Layout: "Monday, January 2 2006 3:04:05 pm"},
+		{Moment: "YYYY-MM-DDTHH:mm:ss.SSSZ", Layout: "2006-01-02T15:04:05.000-07:00"},
+		{Moment: "[Today is] ddd [at] HH:mm", Layout: "Today is Mon at 15:04"},
+		{Moment: "\\D\\a\\y D", Layout: "Day 2"},
+		{Moment: "GGGG-[W]WW-E", Layout: "{isoyear}-W{isoweek}-{weekday}"},
+		{Moment: "X ZZ z DDDD d", Layout: "{unix} -0700 MST 002 {weekday0}"},
+		{Moment: "[;", Layout: "[;"},
+		{Moment: "dd DD", Layout: "Mon 02", Lossy: true},
+		{Moment: "yyyy", Layout: "2006", Lossy: true},
+		{Moment: "DD.MM.yy", Layout: "02.01.06", Lossy: true},
+		{Moment: "HH:mm zzz", Layout: "15:04 MST", Lossy: true},
+		{Moment: "H:mm", Layout: "15:04", Lossy: true},
+		{Moment: "Q YYYY", Layout: "Q 2006", Lossy: true},
+		{Moment: "LL", Layout: "January 2, 2006", Lossy: true},
+		{Moment: "gggg-ww", Layout: "{isoyear}-{isoweek}", Lossy: true},
+		{Moment: "[Mon] D", Layout: "Mon 2", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromMoment(test.Moment)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromMoment %q\nwant=%q lossy=%v\ngot= %q %v", test.Moment, test.Layout, test.Lossy, layout, diags)
+		}
+	}
+}
+
+func TestToMoment(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Moment string
+		Lossy  bool
+	}{
+		{Layout: "2006-01-02 15:04", Moment: "YYYY-MM-DD HH:mm"},
+		{Layout: "{2nd} Jan", Moment: "Do MMM"},
+		{Layout: "Mon Jan 2 3:04pm", Moment: "ddd MMM D h:mma"},
+		{Layout: "2006-01-02T15:04:05.000-07:00", Moment: "YYYY-MM-DD[T]HH:mm:ss.SSSZ"},
+		{Layout: "{isoyear}-W{isoweek}-{weekday} {unix}", Moment: "GGGG[-W]WW-E X"},
+		{Layout: "15:04 [at] day", Moment: "HH:mm \\[\\a\\t\\] \\d\\a\\y"},
+		{Layout: time.RFC3339, Moment: "YYYY-MM-DD[T]HH:mm:ssZ", Lossy: true},
+		{Layout: "_2 Jan", Moment: "D MMM", Lossy: true},
+		{Layout: "15:04:05.000000", Moment: "HH:mm:ss.SSSSSS", Lossy: true},
+		{Layout: "MST", Moment: "z", Lossy: true},
+	}
+	for _, test := range testData {
+		format, diags := ToMoment(test.Layout)
+		if format != test.Moment || (len(diags) > 0) != test.Lossy {
+			t.Errorf("ToMoment %q\nwant=%q lossy=%v\ngot= %q %v", test.Layout, test.Moment, test.Lossy, format, diags)
+		}
+	}
+}
+
+func TestDateFns(t *testing.T) {
+	testData := []struct {
+		DateFns string
+		Layout  string
+		Lossy   bool
+	}{
+		{DateFns: "yyyy-MM-dd HH:mm", Layout: "2006-01-02 15:04"},
+		{DateFns: "do MMM", Layout: "{2nd} Jan"},
+		{DateFns: "EEEE, MMMM d yyyy h:mm:ss aaa", Layout: "Monday, January 2 2006 3:04:05 pm"},
+		{DateFns: "RRRR'-W'II-i t", Layout: "{isoyear}-W{isoweek}-{weekday} {unix}"},
+		{DateFns: "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", Layout: "2006-01-02T15:04:05.000Z07:00"},
+		{DateFns: "YYYY-MM-DD", Layout: "{isoyear}-01-002", Lossy: true},
+		{DateFns: "Mo", Layout: "Mo", Lossy: true},
+		{DateFns: "T", Layout: "T", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromDateFns(test.DateFns)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromDateFns %q\nwant=%q lossy=%v\ngot= %q %v", test.DateFns, test.Layout, test.Lossy, layout, diags)
+			continue
+		}
+		if test.Lossy {
+			continue
+		}
+		if format, diags := ToDateFns(test.Layout); format != test.DateFns || len(diags) > 0 {
+			t.Errorf("ToDateFns %q\nwant=%q\ngot= %q %v", test.Layout, test.DateFns, format, diags)
+		}
+	}
+}
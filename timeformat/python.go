@@ -0,0 +1,87 @@
+package timeformat
+
+import (
+	"sort"
+	"strings"
+)
+
+// pythonDirectives are the directives Python's strptime accepts. Its
+// strftime passes the others on to the C library, so they format on some
+// platforms but never parse.
+const pythonDirectives = "aAbBcdfGHIjmMpSuUVwWxXyYzZ%"
+
+// pythonStrftimeOnly holds the tokens ToStrftime translates to directives
+// outside pythonDirectives.
+var pythonStrftimeOnly = map[Kind]bool{
+	NumMonth: true, Day: true, UnderDay: true, OrdinalDay: true, UnderYearDay: true,
+	UnderHour: true, Hour12: true, UnderHour12: true, Minute: true, Second: true,
+	LowerPM: true, Century: true, ShortISOYear: true, Unix: true, NumColonSecondsTZ: true,
+}
+
+// FromPython translates a format of Python's datetime.strftime and
+// strptime into a layout. The conversions are those of FromStrftime, with
+// %f six digits of microseconds and %:z the offset with a colon, as in
+// Python 3.12. Directives strptime rejects, such as %e or %-d, are
+// reported: they format only where the C library has them.
+//
+// Compiled with timeparse.CompilePython, the layout parses as strptime
+// does, including %f with fewer digits and %z given as Z or +05:30.
+func FromPython(format string) (string, []Diagnostic) {
+	var b builder
+	fromStrftime(&b, format, -1)
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			continue
+		}
+		j := i + 1
+		if format[j] == ':' && j+1 < len(format) && format[j+1] == 'z' {
+			i = j + 1
+			continue
+		}
+		for j < len(format) && strings.IndexByte("-_0^#:123456789", format[j]) >= 0 {
+			j++
+		}
+		if j == len(format) {
+			break
+		}
+		directive := format[i : j+1]
+		_, known := strftime[format[j]]
+		_, composite := strftimeComposite[format[j]]
+		switch {
+		case !known && !composite && strings.IndexByte("%tn", format[j]) < 0:
+			// reported as unknown
+		case j > i+1 || strings.IndexByte(pythonDirectives, format[j]) < 0:
+			b.lossy(i, directive, "platform-specific, Python's strptime rejects it")
+		case format[j] == 'Z':
+			b.lossy(i, directive, "Python names unnamed offsets like UTC+05:30 and its strptime ignores the name")
+		}
+		i = j
+	}
+	layout, diags := b.layout()
+	byOffset(diags)
+	return layout, diags
+}
+
+// ToPython translates a layout into a Python strftime format. Tokens that
+// translate to directives Python's strptime rejects are reported along
+// with those ToStrftime reports.
+func ToPython(layout string) (string, []Diagnostic) {
+	format, diags := ToStrftime(layout)
+	offset := 0
+	for _, token := range Tokenize(layout) {
+		switch {
+		case pythonStrftimeOnly[token.Kind]:
+			diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: "platform-specific in Python, its strptime rejects it"})
+		case token.Kind == TZ:
+			diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: "Python names unnamed offsets like UTC+05:30 and its strptime ignores the name"})
+		}
+		offset += len(token.Text)
+	}
+	byOffset(diags)
+	return format, diags
+}
+
+// byOffset puts the diagnostics of two passes over a format in order.
+func byOffset(diags []Diagnostic) {
+	sort.SliceStable(diags, func(i, j int) bool { return diags[i].Offset < diags[j].Offset })
+}
@@ -0,0 +1,65 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromPython(t *testing.T) {
+	testData := []struct {
+		Python string
+		Layout string
+		Lossy  bool
+	}{
+		{Python: "%Y-%m-%dT%H:%M:%S.%f%z", Layout: "2006-01-02T15:04:05.{frac6}-0700"},
+		{Python: "%Y-%m-%dT%H:%M:%S%:z", Layout: "2006-01-02T15:04:05-07:00"},
+		{Python: "%a %d %b %Y %I:%M %p", Layout: "Mon 02 Jan 2006 03:04 PM"},
+		{Python: "%c|%x|%X", Layout: "Mon Jan _2 15:04:05 2006|01/02/06|15:04:05"},
+		{Python: "%G-W%V-%u %j %%", Layout: "{isoyear}-W{isoweek}-{weekday} 002 %"},
+		{Python: "%Z", Layout: "MST", Lossy: true},
+		{Python: "%-d.%-m.", Layout: "2.1.", Lossy: true},
+		{Python: "%e %k", Layout: "_2 {_15}", Lossy: true},
+		{Python: "%F %T", Layout: "2006-01-02 15:04:05", Lossy: true},
+		{Python: "%::z", Layout: "-07:00:00", Lossy: true},
+		{Python: "%Y%", Layout: "2006%"},
+	}
+	for _, test := range testData {
+		layout, diags := FromPython(test.Python)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromPython %q\nwant=%q lossy=%v\ngot= %q %v", test.Python, test.Layout, test.Lossy, layout, diags)
+		}
+	}
+}
+
+func TestToPython(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Python string
+		Lossy  bool
+	}{
+		{Layout: "2006-01-02T15:04:05.000000-0700", Python: "%Y-%m-%dT%H:%M:%S.%f%z"},
+		{Layout: "2006-01-02 15:04:05-07:00", Python: "%Y-%m-%d %H:%M:%S%:z"},
+		{Layout: time.RFC1123Z, Python: "%a, %d %b %Y %H:%M:%S %z"},
+		{Layout: time.RFC1123, Python: "%a, %d %b %Y %H:%M:%S %Z", Lossy: true},
+		{Layout: time.Kitchen, Python: "%-I:%M%p", Lossy: true},
+		{Layout: time.Stamp, Python: "%b %e %H:%M:%S", Lossy: true},
+	}
+	for _, test := range testData {
+		format, diags := ToPython(test.Layout)
+		if format != test.Python || (len(diags) > 0) != test.Lossy {
+			t.Errorf("ToPython %q\nwant=%q lossy=%v\ngot= %q %v", test.Layout, test.Python, test.Lossy, format, diags)
+		}
+	}
+}
+
+func TestPythonDiagnosticOrder(t *testing.T) {
+	_, diags := FromPython("%e %Q %-d")
+	for i := 1; i < len(diags); i++ {
+		if diags[i].Offset < diags[i-1].Offset {
+			t.Fatalf("diagnostics out of order: %v", diags)
+		}
+	}
+	if len(diags) != 3 {
+		t.Errorf("got %d diagnostics %v, want 3", len(diags), diags)
+	}
+}
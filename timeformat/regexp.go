@@ -0,0 +1,202 @@
+package timeformat
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// regexpGroups names the capture group of each field for NamedRegexp.
+var regexpGroups = map[Kind]string{
+	LongYear: "year", Year: "year",
+	LongMonth: "month", Month: "month", NumMonth: "month", ZeroMonth: "month",
+	LongWeekDay: "weekday", WeekDay: "weekday", ISOWeekDay: "weekday", WeekDayNum: "weekday",
+	Day: "day", UnderDay: "day", ZeroDay: "day", OrdinalDay: "day",
+	UnderYearDay: "yearday", ZeroYearDay: "yearday",
+	Hour: "hour", UnderHour: "hour", Hour12: "hour", ZeroHour12: "hour", UnderHour12: "hour",
+	Minute: "minute", ZeroMinute: "minute",
+	Second: "second", ZeroSecond: "second",
+	PM: "pm", LowerPM: "pm", TZ: "zone",
+	ISO8601TZ: "offset", ISO8601SecondsTZ: "offset", ISO8601ShortTZ: "offset", ISO8601ColonTZ: "offset",
+	ISO8601ColonSecondsTZ: "offset", NumTZ: "offset", NumSecondsTZ: "offset", NumShortTZ: "offset",
+	NumColonTZ: "offset", NumColonSecondsTZ: "offset",
+	FracSecond0: "fraction", FracSecond9: "fraction",
+	Milliseconds: "fraction", Microseconds: "fraction", Nanoseconds: "fraction",
+	Century: "century", ISOYear: "isoyear", ShortISOYear: "isoyear", ISOWeek: "isoweek",
+	SundayWeek: "week", MondayWeek: "week", Unix: "unix",
+}
+
+// regexpNumbers holds the expressions of the numeric fields. Space-padded
+// fields include their padding.
+var regexpNumbers = map[Kind]string{
+	LongYear:     `[0-9]{4}`,
+	Year:         `[0-9]{2}`,
+	NumMonth:     `[1-9]|1[0-2]`,
+	ZeroMonth:    `0[1-9]|1[0-2]`,
+	Day:          `[1-9]|[12][0-9]|3[01]`,
+	UnderDay:     ` [1-9]|[12][0-9]|3[01]`,
+	ZeroDay:      `0[1-9]|[12][0-9]|3[01]`,
+	UnderYearDay: `  [1-9]| [1-9][0-9]|[12][0-9]{2}|3[0-5][0-9]|36[0-6]`,
+	ZeroYearDay:  `00[1-9]|0[1-9][0-9]|[12][0-9]{2}|3[0-5][0-9]|36[0-6]`,
+	Hour:         `[01][0-9]|2[0-3]`,
+	UnderHour:    ` [0-9]|1[0-9]|2[0-3]`,
+	Hour12:       `[1-9]|1[0-2]`,
+	ZeroHour12:   `0[1-9]|1[0-2]`,
+	UnderHour12:  ` [1-9]|1[0-2]`,
+	Minute:       `[1-5]?[0-9]`,
+	ZeroMinute:   `[0-5][0-9]`,
+	Second:       `[1-5]?[0-9]`,
+	ZeroSecond:   `[0-5][0-9]`,
+	Milliseconds: `[0-9]{3}`,
+	Microseconds: `[0-9]{6}`,
+	Nanoseconds:  `[0-9]{9}`,
+	Century:      `[0-9]{2}`,
+	ISOYear:      `[0-9]{4}`,
+	ShortISOYear: `[0-9]{2}`,
+	ISOWeek:      `0[1-9]|[1-4][0-9]|5[0-3]`,
+	ISOWeekDay:   `[1-7]`,
+	WeekDayNum:   `[0-6]`,
+	SundayWeek:   `[0-4][0-9]|5[0-3]`,
+	MondayWeek:   `[0-4][0-9]|5[0-3]`,
+	Unix:         `0|-?[1-9][0-9]*`,
+}
+
+// Regexp returns an RE2 regular expression matching exactly the strings
+// layout formats to in English, see Layout.Regexp.
+func Regexp(layout string) string {
+	return Compile(layout).Regexp()
+}
+
+// NamedRegexp is like Regexp with a named capture group for every field,
+// see Layout.NamedRegexp.
+func NamedRegexp(layout string) string {
+	return Compile(layout).NamedRegexp()
+}
+
+// Regexp returns an anchored RE2 regular expression matching the strings
+// the layout formats to for years 1 through 9999. Every field matches
+// exactly the values it can take, such as 001 through 366 for "002", but
+// fields are not checked against each other, so February 30 or a weekday
+// that does not fit the date are matched. Zone names match letters, or a
+// sign and digits as the tz database writes unnamed zones. The expression
+// only uses syntax that ECMAScript and PCRE share with RE2.
+func (l *Layout) Regexp() string {
+	return l.regexp(false)
+}
+
+// NamedRegexp is like Regexp with a capture group for every field, named
+// after it: year, month, day, weekday, yearday, hour, minute, second,
+// fraction, pm, zone, offset, century, isoyear, isoweek, week or unix.
+// Fields given more than once are numbered from the second on, as in
+// year2. Fraction groups hold the digits only, other groups the text of
+// the field including any padding. Groups are written (?P<name>...), which
+// PCRE accepts too; ECMAScript needs (?<name>...).
+func (l *Layout) NamedRegexp() string {
+	return l.regexp(true)
+}
+
+func (l *Layout) regexp(named bool) string {
+	var out strings.Builder
+	seen := map[string]int{}
+	group := func(kind Kind, expr string) {
+		if !named {
+			out.WriteString("(?:" + expr + ")")
+			return
+		}
+		name := regexpGroups[kind]
+		if seen[name]++; seen[name] > 1 {
+			name += strconv.Itoa(seen[name])
+		}
+		out.WriteString("(?P<" + name + ">" + expr + ")")
+	}
+	out.WriteByte('^')
+	for _, token := range l.tokens {
+		switch token.Kind {
+		case Literal:
+			out.WriteString(quoteRegexp(token.Text))
+		case LongMonth:
+			group(token.Kind, alternatives(l.locale.Months[:]))
+		case Month:
+			group(token.Kind, alternatives(l.locale.ShortMonths[:]))
+		case LongWeekDay:
+			group(token.Kind, alternatives(l.locale.Days[:]))
+		case WeekDay:
+			group(token.Kind, alternatives(l.locale.ShortDays[:]))
+		case OrdinalDay:
+			var days []string
+			for day := 1; day <= 31; day++ {
+				days = append(days, l.locale.Ordinal(day))
+			}
+			group(token.Kind, alternatives(days))
+		case PM:
+			group(token.Kind, alternatives([]string{l.locale.AM, l.locale.PM}))
+		case LowerPM:
+			group(token.Kind, alternatives([]string{strings.ToLower(l.locale.AM), strings.ToLower(l.locale.PM)}))
+		case TZ:
+			group(token.Kind, `[A-Za-z]+|[+-][0-9]{2}(?:[0-5][0-9])?`)
+		case ISO8601TZ, ISO8601SecondsTZ, ISO8601ShortTZ, ISO8601ColonTZ, ISO8601ColonSecondsTZ,
+			NumTZ, NumSecondsTZ, NumShortTZ, NumColonTZ, NumColonSecondsTZ:
+			group(token.Kind, offsetRegexp(token.Kind))
+		case FracSecond0:
+			// digits past the ninth are not written
+			out.WriteString(regexp.QuoteMeta(string(token.Separator())))
+			group(token.Kind, "[0-9]{"+strconv.Itoa(min(token.Digits(), 9))+"}")
+		case FracSecond9:
+			// no trailing zeros, and no separator for a zero fraction
+			out.WriteString("(?:" + regexp.QuoteMeta(string(token.Separator())))
+			group(token.Kind, "[0-9]{0,"+strconv.Itoa(min(token.Digits(), 9)-1)+"}[1-9]")
+			out.WriteString(")?")
+		default:
+			group(token.Kind, regexpNumbers[token.Kind])
+		}
+	}
+	out.WriteByte('$')
+	return out.String()
+}
+
+// quoteRegexp is regexp.QuoteMeta for text that may not be UTF-8. Invalid
+// bytes are read as U+FFFD by the regexp package, and written so.
+func quoteRegexp(text string) string {
+	if utf8.ValidString(text) {
+		return regexp.QuoteMeta(text)
+	}
+	var out strings.Builder
+	for _, r := range text {
+		if r == utf8.RuneError {
+			out.WriteString(`\x{FFFD}`)
+		} else {
+			out.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return out.String()
+}
+
+// alternatives returns an expression matching any of names.
+func alternatives(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteRegexp(name)
+	}
+	return strings.Join(quoted, "|")
+}
+
+// offsetRegexp returns the expression of a zone offset token, following
+// appendOffset.
+func offsetRegexp(kind Kind) string {
+	colon := ""
+	if kind == ISO8601ColonTZ || kind == NumColonTZ || kind == ISO8601ColonSecondsTZ || kind == NumColonSecondsTZ {
+		colon = ":"
+	}
+	expr := `[+-][0-9]{2}`
+	if kind != NumShortTZ && kind != ISO8601ShortTZ {
+		expr += colon + `[0-5][0-9]`
+	}
+	if kind == ISO8601SecondsTZ || kind == NumSecondsTZ || kind == ISO8601ColonSecondsTZ || kind == NumColonSecondsTZ {
+		expr += colon + `[0-5][0-9]`
+	}
+	if kind >= ISO8601TZ && kind <= ISO8601ColonSecondsTZ {
+		expr = "Z|" + expr
+	}
+	return expr
+}
@@ -0,0 +1,135 @@
+package timeformat
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegexp(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Regexp string
+	}{
+		{Layout: "2006-01-02", Regexp: `^(?:[0-9]{4})-(?:0[1-9]|1[0-2])-(?:0[1-9]|[12][0-9]|3[01])$`},
+		{Layout: "15:04:05.000Z07:00", Regexp: `^(?:[01][0-9]|2[0-3]):(?:[0-5][0-9]):(?:[0-5][0-9])\.(?:[0-9]{3})(?:Z|[+-][0-9]{2}:[0-5][0-9])$`},
+		{Layout: "05.999", Regexp: `^(?:[0-5][0-9])(?:\.(?:[0-9]{0,2}[1-9]))?$`},
+		{Layout: "Jan _2 3PM", Regexp: `^(?:Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) (?: [1-9]|[12][0-9]|3[01]) (?:[1-9]|1[0-2])(?:AM|PM)$`},
+		{Layout: "-07 MST (x)", Regexp: `^(?:[+-][0-9]{2}) (?:[A-Za-z]+|[+-][0-9]{2}(?:[0-5][0-9])?) \(x\)$`},
+	}
+	for _, test := range testData {
+		if got := Regexp(test.Layout); got != test.Regexp {
+			t.Errorf("Regexp %q\nwant=%s\ngot= %s", test.Layout, test.Regexp, got)
+		}
+	}
+}
+
+func TestNamedRegexp(t *testing.T) {
+	re := regexp.MustCompile(NamedRegexp("2006-01-02T15:04:05.999999999Z07:00 (2006)"))
+	m := re.FindStringSubmatch("2021-03-07T14:05:09.12+01:00 (2021)")
+	if m == nil {
+		t.Fatalf("%s does not match", re)
+	}
+	got := map[string]string{}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			got[name] = m[i]
+		}
+	}
+	want := map[string]string{"year": "2021", "month": "03", "day": "07", "hour": "14", "minute": "05",
+		"second": "09", "fraction": "12", "offset": "+01:00", "year2": "2021"}
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("group %s = %q, want %q", name, got[name], value)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("groups %v, want %v", got, want)
+	}
+}
+
+// TestRegexpFields checks that each numeric field matches exactly the
+// strings it formats to, out of all strings of up to three digits and
+// spaces.
+func TestRegexpFields(t *testing.T) {
+	var candidates []string
+	const alphabet = "0123456789 "
+	for n, prev := 1, []string{""}; n <= 3; n++ {
+		var next []string
+		for _, p := range prev {
+			for _, c := range alphabet {
+				next = append(next, p+string(c))
+			}
+		}
+		candidates = append(candidates, next...)
+		prev = next
+	}
+	for _, layout := range []string{"1", "01", "2", "_2", "02", "__2", "002", "15", "{_15}", "3", "03", "{_3}",
+		"4", "04", "5", "05", "{isoweek}", "{weekday}", "{weekday0}", "{sunweek}", "{monweek}"} {
+		l := Compile(layout)
+		produced := map[string]bool{}
+		// every day, and every clock field value on some day
+		for i, ts := 0, time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC); ts.Year() < 2026; i, ts = i+1, ts.AddDate(0, 0, 1) {
+			produced[l.Format(ts.Add(time.Duration(i%24)*time.Hour+time.Duration(i%60)*time.Minute+time.Duration(i*7%60)*time.Second))] = true
+		}
+		re := regexp.MustCompile(l.Regexp())
+		for _, s := range candidates {
+			if re.MatchString(s) != produced[s] {
+				t.Errorf("layout %q: %q matched=%v, formatted=%v", layout, s, re.MatchString(s), produced[s])
+			}
+		}
+	}
+}
+
+func TestRegexpLocale(t *testing.T) {
+	re := regexp.MustCompile(Compile("Monday {2nd} January").WithLocale(French).Regexp())
+	for _, s := range []string{"dimanche 1er mars", "lundi 2 août"} {
+		if !re.MatchString(s) {
+			t.Errorf("%s does not match %q", re, s)
+		}
+	}
+	if re.MatchString("Sunday 1st March") {
+		t.Errorf("%s matches English", re)
+	}
+}
+
+// FuzzRegexp checks that what a layout formats to matches its expression.
+func FuzzRegexp(f *testing.F) {
+	for _, layout := range []string{time.RFC3339Nano, time.RFC1123, time.Kitchen, time.StampMicro, time.RFC850,
+		"__2 {_15} {_3} {2nd} {century} {isoyear}-W{isoweek}-{weekday} {unix} {frac3}",
+		"02/01/06 03:04:05,000 PM -070000 Z07 -07:00:00 {weekday0} {sunweek} {monweek} {isoyear2}"} {
+		f.Add(layout, int64(1615125909), int64(120000000), 3600)
+		f.Add(layout, int64(-62135596800), int64(0), 0)
+		f.Add(layout, int64(253402300799), int64(999999999), -5*3600-30*60-15)
+	}
+	f.Fuzz(func(t *testing.T, layout string, sec, nsec int64, offset int) {
+		const minSec, maxSec = -62135596800 + 2*86400, 253402300799 - 2*86400 // years 1 through 9999 in any zone
+		sec = minSec + (sec%(maxSec-minSec)+(maxSec-minSec))%(maxSec-minSec)
+		nsec = (nsec%1e9 + 1e9) % 1e9
+		offset %= 24 * 3600
+		if offset > -60 && offset < 60 {
+			offset = 0
+		}
+		ts := time.Unix(sec, nsec)
+		for _, zone := range []*time.Location{time.UTC, time.FixedZone("", offset), time.FixedZone("XYZT", offset), time.FixedZone("+03", offset)} {
+			s := Format(ts.In(zone), layout)
+			for _, expr := range []string{Regexp(layout), NamedRegexp(layout)} {
+				re, err := regexp.Compile(expr)
+				if err != nil {
+					t.Fatalf("layout %q: %v", layout, err)
+				}
+				if !re.MatchString(s) {
+					t.Fatalf("layout %q formats %s as %q, %s does not match", layout, ts.In(zone), s, expr)
+				}
+			}
+		}
+	})
+}
+
+func TestRegexpGroupNames(t *testing.T) {
+	re := regexp.MustCompile(NamedRegexp("15 03 15"))
+	if got := strings.Join(re.SubexpNames()[1:], ","); got != "hour,hour2,hour3" {
+		t.Errorf("groups %s, want hour,hour2,hour3", got)
+	}
+}
@@ -0,0 +1,160 @@
+package timeformat
+
+import (
+	"strings"
+	"time"
+)
+
+// Named is a well-known layout.
+type Named struct {
+	Name          string // for example "RFC3339" or "HTTP-date"
+	Const         string // the Go identifier holding the layout, if any, for example "time.RFC3339"
+	Layout        string
+	Strftime      string // the layout in strftime, or the closest spelling
+	StrftimeExact bool   // whether Strftime formats exactly like Layout
+	Detect        bool   // whether timeparse.FindAll looks for the layout in free text
+}
+
+// registry holds the named layouts. Reverse lookups prefer earlier entries,
+// so the time package constants come first, and so does FindAll when two
+// layouts match equally long text. Layouts that match plain numbers or
+// times, such as Unix seconds, are not detected.
+var registry = []Named{
+	{Name: "Layout", Const: "time.Layout", Layout: time.Layout},
+	{Name: "ANSIC", Const: "time.ANSIC", Layout: time.ANSIC, Detect: true},
+	{Name: "UnixDate", Const: "time.UnixDate", Layout: time.UnixDate, Detect: true},
+	{Name: "RubyDate", Const: "time.RubyDate", Layout: time.RubyDate, Detect: true},
+	{Name: "RFC822", Const: "time.RFC822", Layout: time.RFC822, Detect: true},
+	{Name: "RFC822Z", Const: "time.RFC822Z", Layout: time.RFC822Z, Detect: true},
+	{Name: "RFC850", Const: "time.RFC850", Layout: time.RFC850, Detect: true},
+	{Name: "RFC1123", Const: "time.RFC1123", Layout: time.RFC1123, Detect: true},
+	{Name: "RFC1123Z", Const: "time.RFC1123Z", Layout: time.RFC1123Z, Detect: true},
+	{Name: "RFC3339", Const: "time.RFC3339", Layout: time.RFC3339, Detect: true},
+	{Name: "RFC3339Nano", Const: "time.RFC3339Nano", Layout: time.RFC3339Nano},
+	{Name: "Kitchen", Const: "time.Kitchen", Layout: time.Kitchen},
+	{Name: "Stamp", Const: "time.Stamp", Layout: time.Stamp},
+	{Name: "StampMilli", Const: "time.StampMilli", Layout: time.StampMilli},
+	{Name: "StampMicro", Const: "time.StampMicro", Layout: time.StampMicro},
+	{Name: "StampNano", Const: "time.StampNano", Layout: time.StampNano},
+	{Name: "DateTime", Const: "time.DateTime", Layout: time.DateTime, Detect: true},
+	{Name: "DateOnly", Const: "time.DateOnly", Layout: time.DateOnly, Detect: true},
+	{Name: "TimeOnly", Const: "time.TimeOnly", Layout: time.TimeOnly},
+	{Name: "HTTP-date", Const: "http.TimeFormat", Layout: "Mon, 02 Jan 2006 15:04:05 GMT"},
+	{Name: "Go String", Layout: "2006-01-02 15:04:05.999999999 -0700 MST", Detect: true},
+	{Name: "ISO 8601 extended", Layout: "2006-01-02T15:04:05Z07:00"},
+	{Name: "ISO 8601 extended milliseconds", Layout: "2006-01-02T15:04:05.000Z07:00"},
+	{Name: "ISO 8601 local", Layout: "2006-01-02T15:04:05", Detect: true},
+	{Name: "ISO 8601 basic", Layout: "20060102T150405Z0700", Detect: true},
+	{Name: "ISO 8601 basic date", Layout: "20060102"},
+	{Name: "ISO 8601 ordinal", Layout: "2006-002T15:04:05Z07:00", Detect: true},
+	{Name: "ISO 8601 ordinal date", Layout: "2006-002", Detect: true},
+	{Name: "ISO 8601 week date", Layout: "{isoyear}-W{isoweek}-{weekday}"},
+	{Name: "syslog", Layout: time.Stamp, Detect: true},
+	{Name: "syslog RFC 5424", Layout: "2006-01-02T15:04:05.999999Z07:00"},
+	{Name: "Apache CLF", Layout: "02/Jan/2006:15:04:05 -0700", Detect: true},
+	{Name: "SQL timestamp", Layout: "2006-01-02 15:04:05.999999"},
+	{Name: "SQL timestamp with time zone", Layout: "2006-01-02 15:04:05.999999-07"},
+	{Name: "SQL timestamp with offset", Layout: "2006-01-02 15:04:05Z07:00", Detect: true},
+	{Name: "SQL timestamp with numeric offset", Layout: "2006-01-02 15:04:05 -0700", Detect: true},
+	{Name: "SQL date", Layout: time.DateOnly},
+	{Name: "Unix seconds", Layout: "{unix}"},
+}
+
+func init() {
+	for i := range registry {
+		n := &registry[i]
+		var diags []Diagnostic
+		n.Strftime, diags = ToStrftime(n.Layout)
+		n.StrftimeExact = len(diags) == 0
+	}
+}
+
+// Registry returns the named layouts.
+func Registry() []Named {
+	return append([]Named(nil), registry...)
+}
+
+// LookupName returns the layout with the given name, ignoring case. The
+// Go identifier, for example "time.RFC3339", works as a name as well.
+func LookupName(name string) (Named, bool) {
+	for _, n := range registry {
+		if strings.EqualFold(n.Name, name) || n.Const != "" && strings.EqualFold(n.Const, name) {
+			return n, true
+		}
+	}
+	return Named{}, false
+}
+
+// LookupLayout returns the named layout that is spelled like layout or,
+// failing that, formats the same fields with the same text in between, for
+// example "15:04:05.{frac3}" for "15:04:05.000". The latter also finds the
+// names of layouts translated from other dialects.
+func LookupLayout(layout string) (Named, bool) {
+	for _, n := range registry {
+		if n.Layout == layout {
+			return n, true
+		}
+	}
+	tokens := canonical(Tokenize(layout))
+	for _, n := range registry {
+		if sameTokens(tokens, canonical(Tokenize(n.Layout))) {
+			return n, true
+		}
+	}
+	return Named{}, false
+}
+
+// LookupStrftime returns the named layout a strftime format formats like.
+func LookupStrftime(format string) (Named, bool) {
+	layout, diags := FromStrftime(format)
+	if len(diags) > 0 {
+		return Named{}, false
+	}
+	return LookupLayout(layout)
+}
+
+// canonical rewrites tokens that have several spellings into one of them:
+// ".000" becomes "." followed by "{frac3}".
+func canonical(tokens []Token) []Token {
+	var out []Token
+	add := func(t Token) {
+		if n := len(out); n > 0 && t.Kind == Literal && out[n-1].Kind == Literal {
+			out[n-1].Text += t.Text
+			return
+		}
+		out = append(out, t)
+	}
+	for _, t := range tokens {
+		if t.Kind == FracSecond0 {
+			if kind, ok := fracKinds[t.Digits()]; ok {
+				add(Token{Literal, string(t.Separator())})
+				add(Token{kind, ""})
+				continue
+			}
+		}
+		add(t)
+	}
+	return out
+}
+
+var fracKinds = map[int]Kind{3: Milliseconds, 6: Microseconds, 9: Nanoseconds}
+
+// sameTokens reports whether two canonical token sequences stand for the
+// same fields and literal text.
+func sameTokens(a, b []Token) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Kind != b[i].Kind {
+			return false
+		}
+		switch a[i].Kind {
+		case Literal, FracSecond0, FracSecond9:
+			if a[i].Text != b[i].Text {
+				return false
+			}
+		}
+	}
+	return true
+}
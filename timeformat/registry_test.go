@@ -0,0 +1,63 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLookupLayout(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Name   string
+	}{
+		{Layout: time.DateOnly, Name: "DateOnly"},
+		{Layout: time.Stamp, Name: "Stamp"},
+		{Layout: "2006-01-02T15:04:05Z07:00", Name: "RFC3339"},
+		{Layout: "Jan _2 15:04:05.{frac3}", Name: "StampMilli"},
+		{Layout: "02/Jan/2006:15:04:05 -0700", Name: "Apache CLF"},
+		{Layout: "2006-01-02 15:04"},
+	}
+	for _, test := range testData {
+		n, ok := LookupLayout(test.Layout)
+		if ok != (test.Name != "") || n.Name != test.Name {
+			t.Errorf("LookupLayout %q want=%q, got=%q %v", test.Layout, test.Name, n.Name, ok)
+		}
+	}
+}
+
+func TestLookupName(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Layout   string
+		Strftime string
+		Exact    bool
+	}{
+		{Name: "time.DateOnly", Layout: "2006-01-02", Strftime: "%Y-%m-%d", Exact: true},
+		{Name: "rfc1123z", Layout: time.RFC1123Z, Strftime: "%a, %d %b %Y %H:%M:%S %z", Exact: true},
+		{Name: "HTTP-date", Layout: "Mon, 02 Jan 2006 15:04:05 GMT", Strftime: "%a, %d %b %Y %H:%M:%S GMT", Exact: true},
+		{Name: "RFC3339", Layout: time.RFC3339, Strftime: "%Y-%m-%dT%H:%M:%S%:z", Exact: false},
+		{Name: "ISO 8601 week date", Layout: "{isoyear}-W{isoweek}-{weekday}", Strftime: "%G-W%V-%u", Exact: true},
+	}
+	for _, test := range testData {
+		n, ok := LookupName(test.Name)
+		if !ok || n.Layout != test.Layout || n.Strftime != test.Strftime || n.StrftimeExact != test.Exact {
+			t.Errorf("LookupName %q\nwant=%q %q %v\ngot= %+v", test.Name, test.Layout, test.Strftime, test.Exact, n)
+		}
+	}
+	if _, ok := LookupName("RFC9999"); ok {
+		t.Errorf("LookupName RFC9999 want no match")
+	}
+}
+
+func TestLookupStrftime(t *testing.T) {
+	for format, want := range map[string]string{
+		"%F":                "DateOnly",
+		"%F %T":             "DateTime",
+		"%b %e %H:%M:%S.%f": "StampMicro",
+		"%Y%m%d":            "ISO 8601 basic date",
+	} {
+		if n, ok := LookupStrftime(format); !ok || n.Name != want {
+			t.Errorf("LookupStrftime %q want=%s, got=%q", format, want, n.Name)
+		}
+	}
+}
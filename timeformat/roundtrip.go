@@ -0,0 +1,315 @@
+package timeformat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Field is a part of a time.Time that may or may not survive formatting
+// followed by parsing.
+type Field int
+
+const (
+	YearField Field = iota
+	MonthField
+	DayField
+	HourField
+	MinuteField
+	SecondField
+	NanosecondField
+	OffsetField   // the zone offset
+	ZoneNameField // the zone abbreviation
+	InstantField  // the absolute point in time
+)
+
+var fieldNames = []string{"year", "month", "day", "hour", "minute", "second", "nanosecond", "offset", "zone name", "instant"}
+
+func (f Field) String() string {
+	if f < 0 || int(f) >= len(fieldNames) {
+		return "unknown field"
+	}
+	return fieldNames[f]
+}
+
+// Survival is the round-trip verdict for one field.
+type Survival struct {
+	Field      Field
+	Guaranteed bool
+	Reason     string // why the field is or is not guaranteed
+	Example    *Loss  // two instants the layout cannot tell apart, nil if guaranteed
+}
+
+// Loss is a pair of instants that differ in a field but format to the same
+// text, so parsing the text cannot give back both.
+type Loss struct {
+	A, B time.Time
+	Text string
+}
+
+// RoundTripReport tells which fields of a time.Time survive formatting with
+// a layout and parsing the result with the same layout.
+type RoundTripReport struct {
+	Layout string
+	Fields []Survival // one per Field, in Field order
+}
+
+// Guaranteed reports whether field survives the round trip for every instant.
+func (r RoundTripReport) Guaranteed(field Field) bool {
+	return r.Fields[field].Guaranteed
+}
+
+// Lost returns the fields that are not guaranteed to survive.
+func (r RoundTripReport) Lost() []Field {
+	var lost []Field
+	for _, s := range r.Fields {
+		if !s.Guaranteed {
+			lost = append(lost, s.Field)
+		}
+	}
+	return lost
+}
+
+func (r RoundTripReport) String() string {
+	var b strings.Builder
+	for _, s := range r.Fields {
+		verdict := "not guaranteed"
+		if s.Guaranteed {
+			verdict = "guaranteed"
+		}
+		fmt.Fprintf(&b, "%s: %s, %s", s.Field, verdict, s.Reason)
+		if s.Example != nil {
+			fmt.Fprintf(&b, " (%s and %s both format as %q)",
+				s.Example.A.Format(exampleLayout), s.Example.B.Format(exampleLayout), s.Example.Text)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// RoundTrip reports which fields of a time.Time survive Format followed by
+// Parse with layout. The verdicts follow from the tokens of the layout and
+// assume years 0 through 9999. With a Unix time the date and clock fields
+// survive as the instant does, in the zone it is parsed in. Where a field is
+// given more than once, the token parsed last decides. Each field that
+// is not guaranteed comes with two instants that differ in it but format
+// the same, when one of the usual suspects does.
+func RoundTrip(layout string) RoundTripReport {
+	l := Compile(layout)
+	has := map[Kind]bool{}
+	fracDigits := 0 // of the fraction parsed last
+	for _, token := range l.tokens {
+		has[token.Kind] = true
+		switch token.Kind {
+		case FracSecond0, FracSecond9:
+			fracDigits = token.Digits()
+		case Milliseconds:
+			fracDigits = 3
+		case Microseconds:
+			fracDigits = 6
+		case Nanoseconds:
+			fracDigits = 9
+		}
+	}
+	hasAny := func(kinds ...Kind) bool {
+		for _, k := range kinds {
+			if has[k] {
+				return true
+			}
+		}
+		return false
+	}
+	// last returns the kind of the last token of kinds, which parsing
+	// keeps, or Literal if there is none
+	last := func(kinds ...Kind) Kind {
+		for i := len(l.tokens) - 1; i >= 0; i-- {
+			for _, k := range kinds {
+				if l.tokens[i].Kind == k {
+					return k
+				}
+			}
+		}
+		return Literal
+	}
+
+	r := RoundTripReport{Layout: layout, Fields: make([]Survival, InstantField+1)}
+	verdict := func(f Field, ok bool, reason string) {
+		r.Fields[f] = Survival{Field: f, Guaranteed: ok, Reason: reason}
+	}
+	if hasAny(Century, ISOYear, ShortISOYear, ISOWeek, SundayWeek, MondayWeek) {
+		for f := range r.Fields {
+			verdict(Field(f), false, "the layout has tokens that can only be formatted, parsing fails")
+		}
+		return r
+	}
+	unix := has[Unix]
+	yday := hasAny(UnderYearDay, ZeroYearDay)
+
+	switch year := last(LongYear, Year); {
+	case unix:
+		verdict(YearField, true, "given by the Unix time")
+	case year == LongYear:
+		verdict(YearField, true, "four-digit year")
+	case year == Year && has[LongYear]:
+		verdict(YearField, false, "two-digit year parsed after the four-digit one, as 1969 through 2068")
+	case year == Year:
+		verdict(YearField, false, "two-digit year, parsed as 1969 through 2068")
+	default:
+		verdict(YearField, false, "no year in the layout")
+	}
+	switch {
+	case unix:
+		verdict(MonthField, true, "given by the Unix time")
+	case hasAny(LongMonth, Month, NumMonth, ZeroMonth):
+		verdict(MonthField, true, "month in the layout")
+	case yday && r.Fields[YearField].Guaranteed:
+		verdict(MonthField, true, "given by the day of the year")
+	case yday:
+		verdict(MonthField, false, "day of the year without a guaranteed year")
+	default:
+		verdict(MonthField, false, "no month in the layout")
+	}
+	switch {
+	case unix:
+		verdict(DayField, true, "given by the Unix time")
+	case hasAny(Day, UnderDay, ZeroDay, OrdinalDay):
+		verdict(DayField, true, "day of the month in the layout")
+	case yday && r.Fields[YearField].Guaranteed:
+		verdict(DayField, true, "given by the day of the year")
+	case yday:
+		verdict(DayField, false, "day of the year without a guaranteed year")
+	case hasAny(LongWeekDay, WeekDay, ISOWeekDay, WeekDayNum):
+		verdict(DayField, false, "only the weekday in the layout, which parsing ignores")
+	default:
+		verdict(DayField, false, "no day in the layout")
+	}
+	switch hour := last(Hour, UnderHour, Hour12, ZeroHour12, UnderHour12); {
+	case unix:
+		verdict(HourField, true, "given by the Unix time")
+	case hour == Hour || hour == UnderHour:
+		verdict(HourField, true, "24-hour clock")
+	case hour != Literal && hasAny(PM, LowerPM):
+		verdict(HourField, true, "12-hour clock with AM/PM")
+	case hour != Literal && hasAny(Hour, UnderHour):
+		verdict(HourField, false, "12-hour clock without AM/PM parsed after the 24-hour clock")
+	case hour != Literal:
+		verdict(HourField, false, "12-hour clock without AM/PM")
+	default:
+		verdict(HourField, false, "no hour in the layout")
+	}
+	switch {
+	case unix:
+		verdict(MinuteField, true, "given by the Unix time")
+	case hasAny(Minute, ZeroMinute):
+		verdict(MinuteField, true, "minute in the layout")
+	default:
+		verdict(MinuteField, false, "no minute in the layout")
+	}
+	switch {
+	case unix:
+		verdict(SecondField, true, "given by the Unix time")
+	case hasAny(Second, ZeroSecond):
+		verdict(SecondField, true, "second in the layout")
+	default:
+		verdict(SecondField, false, "no second in the layout")
+	}
+	switch {
+	case fracDigits >= 9:
+		verdict(NanosecondField, true, "fraction of a second with 9 digits")
+	case fracDigits > 0:
+		verdict(NanosecondField, false, "fraction of a second cut to "+digits(fracDigits))
+	default:
+		verdict(NanosecondField, false, "no fraction of a second in the layout")
+	}
+	offset := last(NumSecondsTZ, NumColonSecondsTZ, ISO8601SecondsTZ, ISO8601ColonSecondsTZ,
+		NumTZ, NumColonTZ, ISO8601TZ, ISO8601ColonTZ, NumShortTZ, ISO8601ShortTZ)
+	switch {
+	case offset == NumSecondsTZ || offset == NumColonSecondsTZ || offset == ISO8601SecondsTZ || offset == ISO8601ColonSecondsTZ:
+		verdict(OffsetField, true, "offset with seconds")
+	case offset == NumShortTZ || offset == ISO8601ShortTZ:
+		verdict(OffsetField, false, "offset in whole hours")
+	case offset != Literal:
+		verdict(OffsetField, false, "offset without seconds, local mean time offsets are cut")
+	case has[TZ]:
+		verdict(OffsetField, false, "zone abbreviation, resolved only against the local zone")
+	default:
+		verdict(OffsetField, false, "no offset in the layout")
+	}
+	if has[TZ] {
+		verdict(ZoneNameField, false, "zone abbreviation in the layout, but zones without one are written as their offset, which does not parse")
+	} else {
+		verdict(ZoneNameField, false, "no zone abbreviation in the layout")
+	}
+	switch {
+	case unix && fracDigits >= 9:
+		verdict(InstantField, true, "Unix time with nanoseconds")
+	case unix:
+		verdict(InstantField, false, "Unix time without nanoseconds")
+	default:
+		verdict(InstantField, true, "every field and the offset survive")
+		for f := YearField; f <= OffsetField; f++ {
+			if !r.Fields[f].Guaranteed {
+				verdict(InstantField, false, "the "+f.String()+" is not guaranteed")
+				break
+			}
+		}
+	}
+
+	for f := range r.Fields {
+		if !r.Fields[f].Guaranteed {
+			r.Fields[f].Example = l.loss(Field(f))
+		}
+	}
+	return r
+}
+
+// exampleLayout shows every field RoundTrip reports on.
+const exampleLayout = "2006-01-02T15:04:05.999999999-07:00:00 MST"
+
+// lossBase is the first instant of each example pair.
+var lossBase = time.Date(2021, time.March, 7, 14, 5, 9, 123456789, time.FixedZone("CET", 3600))
+
+// loss looks for two instants that differ in field but format the same.
+func (l *Layout) loss(field Field) *Loss {
+	a := lossBase
+	zone := func(name string, offset int) time.Time {
+		return time.Date(a.Year(), a.Month(), a.Day(), a.Hour(), a.Minute(), a.Second(), a.Nanosecond(),
+			time.FixedZone(name, offset))
+	}
+	var candidates []time.Time
+	switch field {
+	case YearField:
+		candidates = []time.Time{a.AddDate(-1, 0, 0), a.AddDate(-100, 0, 0), a.AddDate(-28, 0, 0), a.AddDate(-400, 0, 0)}
+	case MonthField:
+		candidates = []time.Time{a.AddDate(0, -1, 0), a.AddDate(0, 1, 0), a.AddDate(0, 0, -7)}
+	case DayField:
+		candidates = []time.Time{a.AddDate(0, 0, -1), a.AddDate(0, 0, -7), a.AddDate(0, 0, -364)}
+	case HourField:
+		candidates = []time.Time{a.Add(-12 * time.Hour), a.Add(-time.Hour)}
+	case MinuteField:
+		candidates = []time.Time{a.Add(-time.Minute)}
+	case SecondField:
+		candidates = []time.Time{a.Add(-time.Second)}
+	case NanosecondField:
+		candidates = []time.Time{a.Add(time.Nanosecond), a.Add(time.Microsecond), a.Add(time.Millisecond)}
+	case OffsetField:
+		candidates = []time.Time{zone("CET", 0), zone("CET", 3600+1), zone("CET", 3600+1800), a.UTC()}
+	case ZoneNameField:
+		// an unnamed zone is written as its offset, like a zone named so
+		a = zone("", 3600)
+		candidates = []time.Time{zone("+0100", 3600), zone("XYZ", 3600)}
+	case InstantField:
+		for f := YearField; f < InstantField; f++ {
+			if loss := l.loss(f); loss != nil && !loss.A.Equal(loss.B) {
+				return loss
+			}
+		}
+	}
+	text := l.Format(a)
+	for _, b := range candidates {
+		if l.Format(b) == text {
+			return &Loss{A: a, B: b, Text: text}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package timeformat
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRoundTrip(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Lost   []Field
+	}{
+		// the zone cases of timeparse/testdata/parse.jsonl
+		{Layout: "2006 01 02 15:04:05 MST", Lost: []Field{NanosecondField, OffsetField, ZoneNameField, InstantField}},
+		{Layout: "2006 01 02 15:04:05Z070000", Lost: []Field{NanosecondField, ZoneNameField, InstantField}},
+		{Layout: "2006 01 02 15:04:05-07:00:00", Lost: []Field{NanosecondField, ZoneNameField, InstantField}},
+
+		{Layout: "2006-01-02T15:04:05.999999999-07:00:00", Lost: []Field{ZoneNameField}},
+		{Layout: time.RFC3339Nano, Lost: []Field{OffsetField, ZoneNameField, InstantField}},
+		{Layout: time.Kitchen, Lost: []Field{YearField, MonthField, DayField, SecondField, NanosecondField, OffsetField, ZoneNameField, InstantField}},
+		{Layout: "Mon 06 15:04:05.000", Lost: []Field{YearField, MonthField, DayField, NanosecondField, OffsetField, ZoneNameField, InstantField}},
+		{Layout: "2006 002 15:04:05.{frac9}-070000", Lost: []Field{ZoneNameField}},
+		{Layout: "{unix}", Lost: []Field{NanosecondField, OffsetField, ZoneNameField, InstantField}},
+		{Layout: "{unix}.{frac9}", Lost: []Field{OffsetField, ZoneNameField}},
+		// the token parsed last decides
+		{Layout: "2006-01-02 15 03PM:04:05.999999999-07:00:00 MST", Lost: []Field{ZoneNameField}},
+		{Layout: "2006-01-02 15 03:04:05.999999999-07:00:00", Lost: []Field{HourField, ZoneNameField, InstantField}},
+		{Layout: "2006-01-02 03 15:04:05.999999999-07:00:00", Lost: []Field{ZoneNameField}},
+		{Layout: "2006-01-02 06 15:04:05.999999999-07:00:00", Lost: []Field{YearField, ZoneNameField, InstantField}},
+		{Layout: "2006-01-02 15:04:05.999999999 .000-07:00:00", Lost: []Field{NanosecondField, ZoneNameField, InstantField}},
+		{Layout: "2006-01-02 15:04:05.999999999-07:00:00 -07", Lost: []Field{OffsetField, ZoneNameField, InstantField}},
+		{Layout: "{isoyear}-W{isoweek}", Lost: []Field{YearField, MonthField, DayField, HourField, MinuteField, SecondField, NanosecondField, OffsetField, ZoneNameField, InstantField}},
+	}
+	for _, test := range testData {
+		r := RoundTrip(test.Layout)
+		if got := r.Lost(); !reflect.DeepEqual(got, test.Lost) {
+			t.Errorf("RoundTrip %q lost\nwant=%v\ngot= %v\n%s", test.Layout, test.Lost, got, r)
+		}
+		for _, s := range r.Fields {
+			if s.Example == nil {
+				continue
+			}
+			a, b := Format(s.Example.A, test.Layout), Format(s.Example.B, test.Layout)
+			if a != s.Example.Text || b != s.Example.Text {
+				t.Errorf("RoundTrip %q %s example formats as %q and %q, want %q", test.Layout, s.Field, a, b, s.Example.Text)
+			}
+		}
+	}
+}
+
+func TestRoundTripExample(t *testing.T) {
+	r := RoundTrip("2006 01 02 15:04:05 MST")
+	loss := r.Fields[OffsetField].Example
+	if loss == nil {
+		t.Fatalf("RoundTrip no example for the offset\n%s", r)
+	}
+	_, a := loss.A.Zone()
+	_, b := loss.B.Zone()
+	if a == b || loss.Text != "2021 03 07 14:05:09 CET" {
+		t.Errorf("RoundTrip offset example %v and %v as %q", loss.A, loss.B, loss.Text)
+	}
+	zone := r.Fields[ZoneNameField].Example
+	if zone == nil || zone.Text != "2021 03 07 14:05:09 +0100" {
+		t.Errorf("RoundTrip zone name example %+v", zone)
+	}
+	if r.Fields[InstantField].Example == nil || r.Fields[InstantField].Example.A.Equal(r.Fields[InstantField].Example.B) {
+		t.Errorf("RoundTrip instant example %+v", r.Fields[InstantField].Example)
+	}
+}
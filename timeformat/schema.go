@@ -0,0 +1,290 @@
+package timeformat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is a JSON Schema fragment for string fields formatted with a
+// layout. It is also an OpenAPI schema object.
+type Schema struct {
+	Type     string `json:"type"`                 // always "string"
+	Format   string `json:"format,omitempty"`     // date-time, date or time for the forms of RFC 3339
+	Pattern  string `json:"pattern"`              // see Regexp
+	Example  string `json:"example"`              // Reference formatted with the layout
+	GoLayout string `json:"x-go-layout"`          // the layout
+	Strftime string `json:"x-strftime,omitempty"` // the strftime format, if the translation is exact
+}
+
+// rfc3339Formats maps the layouts of RFC 3339, without fractions of a
+// second, to their JSON Schema format.
+var rfc3339Formats = map[string]string{
+	"2006-01-02T15:04:05Z07:00": "date-time",
+	"2006-01-02T15:04:05-07:00": "date-time",
+	"2006-01-02":                "date",
+	"15:04:05Z07:00":            "time",
+	"15:04:05-07:00":            "time",
+}
+
+// JSONSchema returns the schema of string fields formatted with layout.
+func JSONSchema(layout string) Schema {
+	s := Schema{
+		Type:     "string",
+		Pattern:  Regexp(layout),
+		Example:  Format(Reference, layout),
+		GoLayout: layout,
+	}
+	if format, diags := ToStrftime(layout); len(diags) == 0 {
+		s.Strftime = format
+	}
+	// a fraction after the seconds is allowed in all of them
+	var plain strings.Builder
+	tokens := Tokenize(layout)
+	for i, token := range tokens {
+		if (token.Kind == FracSecond0 || token.Kind == FracSecond9) && token.Separator() == '.' &&
+			i > 0 && tokens[i-1].Kind == ZeroSecond {
+			continue
+		}
+		plain.WriteString(token.Text)
+	}
+	s.Format = rfc3339Formats[plain.String()]
+	return s
+}
+
+// FieldError is a field of a JSON document that does not fit the layout
+// its schema gives.
+type FieldError struct {
+	Path    string // JSON Pointer to the field, such as /orders/0/created
+	Layout  string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return path + ": " + e.Message
+}
+
+// ValidateJSON checks the fields of document whose schema has an
+// x-go-layout, or an x-strftime that translates exactly, against that
+// layout. A field fits if it is a string matching the layout's Regexp and,
+// for layouts without extended tokens, time.Parse accepts it, which rules
+// out dates such as February 30. Zone names are only checked against the
+// pattern, as Format writes offsets such as +0530 for unnamed zones.
+//
+// The schema is followed through properties, additionalProperties, items,
+// allOf and local $ref references, which are resolved against the schema
+// document, so the schema may be an OpenAPI document with a top-level
+// $ref to one of its components. Null values and all other keywords are
+// left to a JSON Schema validator. The error is for invalid JSON and
+// references that cannot be resolved.
+func ValidateJSON(schema, document []byte) ([]FieldError, error) {
+	var v validator
+	if err := json.Unmarshal(schema, &v.root); err != nil {
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	var doc any
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("document: %w", err)
+	}
+	if err := v.walk(v.root, doc, "", 0); err != nil {
+		return nil, err
+	}
+	return v.errs, nil
+}
+
+// validator walks a schema and a document together.
+type validator struct {
+	root     any
+	errs     []FieldError
+	checkers map[string]*checker
+}
+
+// checker holds what checking the fields of one layout needs.
+type checker struct {
+	re    *regexp.Regexp // Regexp of the layout
+	zones *regexp.Regexp // NamedRegexp, if the layout has zone names
+	parse bool           // whether time.Parse reads the layout
+}
+
+func newChecker(layout string) *checker {
+	c := &checker{re: regexp.MustCompile(Regexp(layout)), parse: true}
+	for _, token := range Tokenize(layout) {
+		switch {
+		case token.Kind >= OrdinalDay:
+			c.parse = false
+		case token.Kind == TZ:
+			c.zones = regexp.MustCompile(NamedRegexp(layout))
+		}
+	}
+	return c
+}
+
+// maxRefs bounds the chain of references followed without descending into
+// the document, which would otherwise loop on a schema that refers to
+// itself.
+const maxRefs = 32
+
+func (v *validator) walk(schema, value any, path string, refs int) error {
+	s, ok := schema.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		if refs == maxRefs {
+			return fmt.Errorf("schema: reference %q: too many references", ref)
+		}
+		target, err := resolve(v.root, ref)
+		if err != nil {
+			return err
+		}
+		if err := v.walk(target, value, path, refs+1); err != nil {
+			return err
+		}
+	}
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			if err := v.walk(sub, value, path, refs+1); err != nil {
+				return err
+			}
+		}
+	}
+	if layout, ok := schemaLayout(s); ok && value != nil {
+		v.check(layout, value, path)
+	}
+	switch value := value.(type) {
+	case map[string]any:
+		properties, _ := s["properties"].(map[string]any)
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			sub, ok := properties[key]
+			if !ok {
+				sub = s["additionalProperties"]
+			}
+			if err := v.walk(sub, value[key], path+"/"+escapePointer(key), 0); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, item := range value {
+			if err := v.walk(s["items"], item, path+"/"+strconv.Itoa(i), 0); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaLayout returns the layout a schema gives its field.
+func schemaLayout(s map[string]any) (string, bool) {
+	if layout, ok := s["x-go-layout"].(string); ok {
+		return layout, true
+	}
+	if format, ok := s["x-strftime"].(string); ok {
+		if layout, diags := FromStrftime(format); len(diags) == 0 {
+			return layout, true
+		}
+	}
+	return "", false
+}
+
+// check adds an error if value does not fit layout.
+func (v *validator) check(layout string, value any, path string) {
+	fail := func(format string, args ...any) {
+		v.errs = append(v.errs, FieldError{Path: path, Layout: layout, Message: fmt.Sprintf(format, args...)})
+	}
+	s, ok := value.(string)
+	if !ok {
+		fail("want a string formatted as %q, got %T", layout, value)
+		return
+	}
+	c, ok := v.checkers[layout]
+	if !ok {
+		c = newChecker(layout)
+		if v.checkers == nil {
+			v.checkers = map[string]*checker{}
+		}
+		v.checkers[layout] = c
+	}
+	if !c.re.MatchString(s) {
+		fail("%q does not match layout %q", s, layout)
+		return
+	}
+	if !c.parse {
+		return
+	}
+	parsed := s
+	if c.zones != nil {
+		// Format writes the offset of an unnamed zone, such as +0530, as
+		// its name, which time.Parse rejects; the pattern checked the names
+		parsed = replaceZones(c.zones, s)
+	}
+	if _, err := time.Parse(layout, parsed); err != nil {
+		var pe *time.ParseError
+		if errors.As(err, &pe) {
+			pe.Value = s
+		}
+		fail("%v", err)
+	}
+}
+
+// replaceZones returns s with the zone names re matches replaced by UTC.
+func replaceZones(re *regexp.Regexp, s string) string {
+	m := re.FindStringSubmatchIndex(s)
+	names := re.SubexpNames()
+	for i := len(names) - 1; i > 0; i-- {
+		if strings.HasPrefix(names[i], "zone") && m[2*i] >= 0 {
+			s = s[:m[2*i]] + "UTC" + s[m[2*i+1]:]
+		}
+	}
+	return s
+}
+
+// resolve returns the value a local reference such as
+// "#/components/schemas/Order" points to in root.
+func resolve(root any, ref string) (any, error) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("schema: reference %q is not local", ref)
+	}
+	value := root
+	if pointer == "" {
+		return value, nil
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+		switch node := value.(type) {
+		case map[string]any:
+			value, ok = node[part]
+		case []any:
+			i, err := strconv.Atoi(part)
+			ok = err == nil && i >= 0 && i < len(node)
+			if ok {
+				value = node[i]
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, fmt.Errorf("schema: reference %q cannot be resolved", ref)
+		}
+	}
+	return value, nil
+}
+
+// escapePointer escapes a key for use in a JSON Pointer.
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
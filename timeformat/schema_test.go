@@ -0,0 +1,154 @@
+package timeformat
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONSchema(t *testing.T) {
+	testData := []struct {
+		Layout   string
+		Format   string
+		Example  string
+		Strftime string
+	}{
+		{Layout: "2006-01-02T15:04:05Z07:00", Format: "date-time", Example: "2021-03-07T14:05:09+01:00"},
+		{Layout: "2006-01-02T15:04:05.999999999-07:00", Format: "date-time", Example: "2021-03-07T14:05:09.12+01:00"},
+		{Layout: "2006-01-02T15:04:05.000Z07:00", Format: "date-time", Example: "2021-03-07T14:05:09.120+01:00"},
+		{Layout: "2006-01-02", Format: "date", Example: "2021-03-07", Strftime: "%Y-%m-%d"},
+		{Layout: "15:04:05Z07:00", Format: "time", Example: "14:05:09+01:00"},
+		{Layout: "15:04:05", Example: "14:05:09", Strftime: "%H:%M:%S"},
+		{Layout: "2006-01-02T15:04:05-07:00", Format: "date-time", Example: "2021-03-07T14:05:09+01:00", Strftime: "%Y-%m-%dT%H:%M:%S%:z"},
+		{Layout: "2006-01-02T15:04.000Z07:00", Example: "2021-03-07T14:05.120+01:00"},
+		{Layout: "02/01/2006 15:04 MST", Example: "07/03/2021 14:05 CET", Strftime: "%d/%m/%Y %H:%M %Z"},
+	}
+	for _, test := range testData {
+		got := JSONSchema(test.Layout)
+		want := Schema{Type: "string", Format: test.Format, Pattern: Regexp(test.Layout),
+			Example: test.Example, GoLayout: test.Layout, Strftime: test.Strftime}
+		if got != want {
+			t.Errorf("JSONSchema %q\nwant=%+v\ngot= %+v", test.Layout, want, got)
+		}
+		if !regexp.MustCompile(got.Pattern).MatchString(got.Example) {
+			t.Errorf("JSONSchema %q: example %q does not match %s", test.Layout, got.Example, got.Pattern)
+		}
+	}
+}
+
+func TestJSONSchemaMarshal(t *testing.T) {
+	b, err := json.Marshal(JSONSchema("15:04"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"string","pattern":"^(?:[01][0-9]|2[0-3]):(?:[0-5][0-9])$","example":"14:05","x-go-layout":"15:04","x-strftime":"%H:%M"}`
+	if string(b) != want {
+		t.Errorf("want=%s\ngot= %s", want, b)
+	}
+}
+
+const orderSchema = `{
+	"openapi": "3.0.3",
+	"$ref": "#/components/schemas/Order",
+	"components": {"schemas": {
+		"Order": {
+			"type": "object",
+			"properties": {
+				"created": {"$ref": "#/components/schemas/Timestamp"},
+				"due": {"type": "string", "x-go-layout": "2006-01-02"},
+				"slots": {"type": "array", "items": {"x-strftime": "%H:%M"}},
+				"notes": {"type": "object", "additionalProperties": {"x-go-layout": "Jan _2"}},
+				"parent": {"$ref": "#/components/schemas/Order"},
+				"extra": {"allOf": [{"description": "x"}, {"x-go-layout": "2006"}]}
+			}
+		},
+		"Timestamp": {"type": "string", "x-go-layout": "2006-01-02T15:04:05Z07:00"}
+	}}
+}`
+
+func TestValidateJSON(t *testing.T) {
+	testData := []struct {
+		Document string
+		Errors   []string
+	}{
+		{Document: `{"created": "2021-03-07T14:05:09Z", "due": "2021-03-08", "slots": ["09:00", "14:30"]}`},
+		{Document: `{"created": null, "notes": {"a/b": "Mar  7"}, "extra": "2021", "other": 1}`},
+		{Document: `{"created": "2021-03-07 14:05:09Z"}`,
+			Errors: []string{`/created: "2021-03-07 14:05:09Z" does not match layout "2006-01-02T15:04:05Z07:00"`}},
+		{Document: `{"due": "2021-02-30"}`,
+			Errors: []string{`/due: parsing time "2021-02-30": day out of range`}},
+		{Document: `{"due": 20210307, "slots": ["9:00", "24:00"]}`,
+			Errors: []string{
+				`/due: want a string formatted as "2006-01-02", got float64`,
+				`/slots/0: "9:00" does not match layout "15:04"`,
+				`/slots/1: "24:00" does not match layout "15:04"`,
+			}},
+		{Document: `{"notes": {"a/b~": "Mar 7"}, "extra": "21"}`,
+			Errors: []string{
+				`/extra: "21" does not match layout "2006"`,
+				`/notes/a~1b~0: "Mar 7" does not match layout "Jan _2"`,
+			}},
+		{Document: `{"parent": {"parent": {"due": "07/03/2021"}}}`,
+			Errors: []string{`/parent/parent/due: "07/03/2021" does not match layout "2006-01-02"`}},
+	}
+	for _, test := range testData {
+		errs, err := ValidateJSON([]byte(orderSchema), []byte(test.Document))
+		if err != nil {
+			t.Errorf("ValidateJSON %s: %v", test.Document, err)
+			continue
+		}
+		var got []string
+		for _, e := range errs {
+			got = append(got, e.Error())
+		}
+		if strings.Join(got, "\n") != strings.Join(test.Errors, "\n") {
+			t.Errorf("ValidateJSON %s\nwant=%q\ngot= %q", test.Document, test.Errors, got)
+		}
+	}
+}
+
+func TestValidateJSONZone(t *testing.T) {
+	const layout = "2006-01-02 15:04 MST"
+	schema, err := json.Marshal(JSONSchema(layout))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ist := time.FixedZone("", 19800)
+	for _, value := range []string{
+		Format(time.Date(2021, 3, 7, 14, 5, 0, 0, ist), layout),
+		Format(time.Date(2021, 3, 7, 14, 5, 0, 0, time.FixedZone("", -3*3600)), layout),
+		"2021-03-07 14:05 CET",
+	} {
+		document, _ := json.Marshal(value)
+		errs, err := ValidateJSON(schema, document)
+		if err != nil || len(errs) > 0 {
+			t.Errorf("ValidateJSON %s: %v %v", document, errs, err)
+		}
+	}
+	errs, err := ValidateJSON(schema, []byte(`"2021-02-30 14:05 +0530"`))
+	if want := `/: parsing time "2021-02-30 14:05 +0530": day out of range`; err != nil || len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("ValidateJSON February 30\nwant=%s\ngot= %v %v", want, errs, err)
+	}
+}
+
+func TestValidateJSONErrors(t *testing.T) {
+	testData := []struct {
+		Schema   string
+		Document string
+		Error    string
+	}{
+		{Schema: `{`, Document: `{}`, Error: "schema: unexpected end of JSON input"},
+		{Schema: `{}`, Document: `[`, Error: "document: unexpected end of JSON input"},
+		{Schema: `{"$ref": "other.json#/x"}`, Document: `{}`, Error: `schema: reference "other.json#/x" is not local`},
+		{Schema: `{"$ref": "#/definitions/x"}`, Document: `{}`, Error: `schema: reference "#/definitions/x" cannot be resolved`},
+		{Schema: `{"a": {"$ref": "#/a"}, "$ref": "#/a"}`, Document: `{}`, Error: `schema: reference "#/a": too many references`},
+	}
+	for _, test := range testData {
+		_, err := ValidateJSON([]byte(test.Schema), []byte(test.Document))
+		if err == nil || err.Error() != test.Error {
+			t.Errorf("ValidateJSON %s %s\nwant=%s\ngot= %v", test.Schema, test.Document, test.Error, err)
+		}
+	}
+}
@@ -0,0 +1,615 @@
+package timeformat
+
+import (
+	"sort"
+	"strings"
+)
+
+// postgresKeywords and oracleKeywords are the template patterns of
+// PostgreSQL and Oracle, matched case-insensitively with the longest first.
+var (
+	postgresKeywords = []string{
+		"Y,YYY", "YYYY", "YYY", "YY", "Y", "IYYY", "IYY", "IY", "I",
+		"HH24", "HH12", "HH", "MI", "SSSSS", "SSSS", "SS", "MS", "US",
+		"FF1", "FF2", "FF3", "FF4", "FF5", "FF6",
+		"AM", "PM", "A.M.", "P.M.", "BC", "AD", "B.C.", "A.D.",
+		"MONTH", "MON", "MM", "DAY", "DY", "DDD", "DD", "D", "IDDD", "ID",
+		"IW", "WW", "W", "CC", "J", "Q", "RM", "TZH:TZM", "TZHTZM", "TZH", "TZM", "TZ", "OF",
+	}
+	oracleKeywords = []string{
+		"Y,YYY", "SYYYY", "YYYY", "YYY", "YY", "Y", "RRRR", "RR", "YEAR", "IYYY", "IYY", "IY", "I",
+		"HH24", "HH12", "HH", "MI", "SSSSS", "SS", "X",
+		"FF1", "FF2", "FF3", "FF4", "FF5", "FF6", "FF7", "FF8", "FF9", "FF",
+		"AM", "PM", "A.M.", "P.M.", "BC", "AD", "B.C.", "A.D.",
+		"MONTH", "MON", "MM", "DAY", "DY", "DDD", "DD", "D", "DL", "DS", "TS",
+		"IW", "WW", "W", "CC", "J", "Q", "RM", "TZH:TZM", "TZHTZM", "TZH", "TZM", "TZD", "TZR",
+	}
+)
+
+func init() {
+	for _, keywords := range [][]string{postgresKeywords, oracleKeywords} {
+		sort.SliceStable(keywords, func(i, j int) bool { return len(keywords[i]) > len(keywords[j]) })
+	}
+}
+
+// sqlNameCase returns a message unless text, a name keyword, is written
+// capitalized like the names Go prints.
+func sqlNameCase(text string) string {
+	switch {
+	case text == strings.ToUpper(text):
+		return "no layout token for upper-case names"
+	case text == strings.ToLower(text):
+		return "no layout token for lower-case names"
+	}
+	return ""
+}
+
+// sqlField returns the token the template pattern key, written as text,
+// formats as in fill mode fm or without it, with a message if the token is
+// not exact. A zero token means there is none.
+func sqlField(key, text string, fm, oracle bool) (Token, string) {
+	numeric := func(padded, fill Token) (Token, string) {
+		if fm {
+			return fill, ""
+		}
+		return padded, ""
+	}
+	switch key {
+	case "YYYY", "RRRR":
+		return Token{LongYear, "2006"}, ""
+	case "YY", "RR":
+		return Token{Year, "06"}, ""
+	case "IYYY":
+		return Token{ISOYear, "{isoyear}"}, ""
+	case "IY":
+		return Token{ShortISOYear, "{isoyear2}"}, ""
+	case "Y,YYY", "SYYYY", "YYY", "Y", "IYY", "I":
+		return Token{LongYear, "2006"}, "no layout token for this year form"
+	case "MM":
+		return numeric(Token{ZeroMonth, "01"}, Token{NumMonth, "1"})
+	case "DD":
+		return numeric(Token{ZeroDay, "02"}, Token{Day, "2"})
+	case "DDD":
+		if fm {
+			return Token{ZeroYearDay, "002"}, "no layout token for an unpadded day of year"
+		}
+		return Token{ZeroYearDay, "002"}, ""
+	case "HH", "HH12":
+		return numeric(Token{ZeroHour12, "03"}, Token{Hour12, "3"})
+	case "HH24":
+		if fm {
+			return Token{Hour, "15"}, "no layout token for an unpadded 24-hour clock"
+		}
+		return Token{Hour, "15"}, ""
+	case "MI":
+		return numeric(Token{ZeroMinute, "04"}, Token{Minute, "4"})
+	case "SS":
+		return numeric(Token{ZeroSecond, "05"}, Token{Second, "5"})
+	case "IW":
+		if fm {
+			return Token{ISOWeek, "{isoweek}"}, "no layout token for an unpadded week"
+		}
+		return Token{ISOWeek, "{isoweek}"}, ""
+	case "ID":
+		return Token{ISOWeekDay, "{weekday}"}, ""
+	case "D":
+		return Token{WeekDayNum, "{weekday0}"}, "days are numbered 1-7 from Sunday"
+	case "CC":
+		return Token{Century, "{century}"}, "centuries start at the year 1, 2001 is in the 21st"
+	case "MONTH", "DAY":
+		token := Token{LongMonth, "January"}
+		if key == "DAY" {
+			token = Token{LongWeekDay, "Monday"}
+		}
+		if message := sqlNameCase(text); message != "" {
+			return token, message
+		}
+		if !fm {
+			return token, "names are blank-padded to nine characters without FM"
+		}
+		return token, ""
+	case "MON", "DY":
+		token := Token{Month, "Jan"}
+		if key == "DY" {
+			token = Token{WeekDay, "Mon"}
+		}
+		return token, sqlNameCase(text)
+	case "AM", "PM":
+		if text == strings.ToLower(text) {
+			return Token{LowerPM, "pm"}, ""
+		}
+		return Token{PM, "PM"}, ""
+	case "A.M.", "P.M.":
+		return Token{PM, "PM"}, "no layout token for AM/PM with periods"
+	case "TZH:TZM":
+		return Token{NumColonTZ, "-07:00"}, ""
+	case "TZHTZM":
+		return Token{NumTZ, "-0700"}, ""
+	case "TZH":
+		return Token{NumShortTZ, "-07"}, ""
+	case "TZ", "TZD":
+		if text == strings.ToLower(text) {
+			return Token{TZ, "MST"}, "no layout token for lower-case zone names"
+		}
+		return Token{TZ, "MST"}, ""
+	case "TZR":
+		return Token{TZ, "MST"}, "no layout token for zone regions"
+	case "OF":
+		return Token{NumColonTZ, "-07:00"}, "PostgreSQL prints offset minutes only when non-zero"
+	case "X":
+		return Token{Literal, "."}, ""
+	case "DL", "DS", "TS":
+		return Token{}, "no layout token for localized formats"
+	}
+	return Token{}, "no layout token for this template pattern"
+}
+
+// FromPostgres translates a PostgreSQL to_char or to_timestamp template into
+// a layout. The FM prefix turns off padding for the next pattern, the TH
+// and th suffixes add an English ordinal, and quoted text is copied.
+// Patterns with no exact counterpart are reported and translated to the
+// closest token, or kept as literal text.
+func FromPostgres(template string) (string, []Diagnostic) {
+	return fromSQL(template, false)
+}
+
+// FromOracle translates an Oracle TO_CHAR or TO_DATE mask into a layout.
+// Unlike in PostgreSQL, FM switches fill mode on and off for the rest of
+// the mask. X is the radix character of the default territory, '.'.
+func FromOracle(mask string) (string, []Diagnostic) {
+	return fromSQL(mask, true)
+}
+
+// fromSQL translates a PostgreSQL or, if oracle is set, an Oracle template.
+func fromSQL(template string, oracle bool) (string, []Diagnostic) {
+	keywords := postgresKeywords
+	if oracle {
+		keywords = oracleKeywords
+	}
+	var b builder
+	fm, fmNext := false, false
+next:
+	for i := 0; i < len(template); {
+		if template[i] == '"' {
+			var text strings.Builder
+			j := i + 1
+			for ; j < len(template) && template[j] != '"'; j++ {
+				if template[j] == '\\' && j+1 < len(template) && !oracle {
+					j++
+				}
+				text.WriteByte(template[j])
+			}
+			if j == len(template) {
+				b.lossy(i, template[i:], "unterminated quote")
+			}
+			b.literal(i, text.String())
+			i = j + 1
+			continue
+		}
+		if hasPrefixFold(template[i:], "FM") {
+			if oracle {
+				fm = !fm
+			} else {
+				fmNext = true
+			}
+			i += 2
+			continue
+		}
+		if hasPrefixFold(template[i:], "FX") {
+			// exact matching when parsing, no effect on formatting
+			i += 2
+			continue
+		}
+		for _, key := range keywords {
+			if !hasPrefixFold(template[i:], key) {
+				continue
+			}
+			offset, text := i, template[i:i+len(key)]
+			i += len(key)
+			if strings.HasPrefix(key, "FF") || key == "MS" || key == "US" {
+				sqlFraction(&b, offset, key)
+				continue next
+			}
+			token, message := sqlField(key, text, fm || fmNext, oracle)
+			fmNext = false
+			if suffix := template[i:min(i+2, len(template))]; suffix == "TH" || suffix == "th" {
+				text += suffix
+				i += 2
+				switch {
+				case key == "DD" && token.Kind == Day && suffix == "th":
+					token = Token{OrdinalDay, "{2nd}"}
+				case message == "":
+					message = "no layout token for this ordinal"
+				}
+			}
+			if message != "" {
+				b.lossy(offset, text, message)
+			}
+			switch {
+			case token.Text == "":
+				b.literal(offset, text)
+			case token.Kind == Literal:
+				b.literal(offset, token.Text)
+			default:
+				b.token(offset, token.Kind, token.Text)
+			}
+			continue next
+		}
+		b.literal(i, template[i:i+1])
+		i++
+	}
+	return b.layout()
+}
+
+// sqlFraction adds the fraction of the pattern key: FF with a digit count,
+// MS or US. Oracle's FF without a count is taken as six digits.
+func sqlFraction(b *builder, offset int, key string) {
+	n := 6
+	switch {
+	case key == "MS":
+		n = 3
+	case key == "FF":
+		b.lossy(offset, key, "FF prints the precision of the column, taken as six digits")
+	case len(key) == 3:
+		n = int(key[2] - '0')
+	}
+	if b.fraction(offset, FracSecond0, n) != 0 {
+		return
+	}
+	switch n {
+	case 3:
+		b.token(offset, Milliseconds, "{frac3}")
+	case 6:
+		b.token(offset, Microseconds, "{frac6}")
+	case 9:
+		b.token(offset, Nanoseconds, "{frac9}")
+	default:
+		b.literal(offset, key)
+		b.lossy(offset, key, "no layout token for this many fraction digits without a separator")
+	}
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// ToPostgres translates a layout into a PostgreSQL to_char template. Tokens
+// with no exact counterpart are reported and translated to the closest
+// pattern.
+func ToPostgres(layout string) (string, []Diagnostic) {
+	return toSQL(layout, false)
+}
+
+// ToOracle translates a layout into an Oracle TO_CHAR mask.
+func ToOracle(layout string) (string, []Diagnostic) {
+	return toSQL(layout, true)
+}
+
+// toSQL translates a layout into a PostgreSQL or, if oracle is set, an
+// Oracle template. Fill mode is written as a prefix on each pattern in
+// PostgreSQL and switched only when it changes in Oracle.
+func toSQL(layout string, oracle bool) (string, []Diagnostic) {
+	var out strings.Builder
+	var diags []Diagnostic
+	lossy := func(offset int, token Token, message string) {
+		diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: message})
+	}
+	fm := false
+	// pattern writes key in fill mode fill; any leaves the mode as it is.
+	pattern := func(key string, fill, any bool) {
+		switch {
+		case any:
+		case !oracle && fill:
+			out.WriteString("FM")
+		case oracle && fill != fm:
+			out.WriteString("FM")
+			fm = fill
+		}
+		out.WriteString(key)
+	}
+	offset := 0
+	for _, token := range Tokenize(layout) {
+		switch token.Kind {
+		case Literal:
+			out.WriteString(quoteSQL(token.Text))
+		case LongYear:
+			pattern("YYYY", false, true)
+		case Year:
+			pattern("YY", false, true)
+		case LongMonth:
+			pattern("Month", true, false)
+		case Month:
+			pattern("Mon", false, true)
+		case NumMonth:
+			pattern("MM", true, false)
+		case ZeroMonth:
+			pattern("MM", false, false)
+		case LongWeekDay:
+			pattern("Day", true, false)
+		case WeekDay:
+			pattern("Dy", false, true)
+		case Day:
+			pattern("DD", true, false)
+		case ZeroDay:
+			pattern("DD", false, false)
+		case OrdinalDay:
+			pattern("DDth", true, false)
+		case ZeroYearDay:
+			pattern("DDD", false, false)
+		case Hour:
+			pattern("HH24", false, false)
+		case Hour12:
+			pattern("HH12", true, false)
+		case ZeroHour12:
+			pattern("HH12", false, false)
+		case Minute:
+			pattern("MI", true, false)
+		case ZeroMinute:
+			pattern("MI", false, false)
+		case Second:
+			pattern("SS", true, false)
+		case ZeroSecond:
+			pattern("SS", false, false)
+		case PM:
+			pattern("AM", false, true)
+		case LowerPM:
+			pattern("am", false, true)
+		case TZ:
+			if oracle {
+				pattern("TZD", false, true)
+			} else {
+				pattern("TZ", false, true)
+			}
+		case NumColonTZ:
+			pattern("TZH:TZM", false, true)
+		case NumTZ:
+			pattern("TZHTZM", false, true)
+		case NumShortTZ:
+			pattern("TZH", false, true)
+		case ISO8601TZ, ISO8601ShortTZ, ISO8601SecondsTZ, NumSecondsTZ:
+			pattern("TZHTZM", false, true)
+			lossy(offset, token, "no such offset form, using TZHTZM")
+		case ISO8601ColonTZ, ISO8601ColonSecondsTZ, NumColonSecondsTZ:
+			pattern("TZH:TZM", false, true)
+			lossy(offset, token, "no such offset form, using TZH:TZM")
+		case FracSecond0, FracSecond9:
+			n, most := token.Digits(), 6
+			if oracle {
+				most = 9
+			}
+			if n > most {
+				lossy(offset, token, "too many fraction digits")
+				n = most
+			}
+			out.WriteByte(token.Separator())
+			pattern("FF"+string(rune('0'+n)), false, true)
+			if token.Kind == FracSecond9 {
+				lossy(offset, token, "templates cannot omit trailing zeros")
+			}
+		case Milliseconds:
+			pattern("FF3", false, true)
+		case Microseconds:
+			pattern("FF6", false, true)
+		case Nanoseconds:
+			if oracle {
+				pattern("FF9", false, true)
+			} else {
+				pattern("US", false, true)
+				lossy(offset, token, "PostgreSQL has microsecond precision")
+			}
+		case ISOYear:
+			pattern("IYYY", false, true)
+		case ShortISOYear:
+			pattern("IY", false, true)
+		case ISOWeek:
+			pattern("IW", false, false)
+		case ISOWeekDay:
+			if oracle {
+				pattern("D", false, true)
+				lossy(offset, token, "Oracle numbers days by territory")
+			} else {
+				pattern("ID", false, true)
+			}
+		case Century:
+			pattern("CC", false, false)
+			lossy(offset, token, "centuries start at the year 1, 2001 is in the 21st")
+		case UnderDay, UnderHour, UnderHour12:
+			key := map[Kind]string{UnderDay: "DD", UnderHour: "HH24", UnderHour12: "HH12"}[token.Kind]
+			pattern(key, true, false)
+			lossy(offset, token, "templates cannot pad with spaces")
+		default:
+			out.WriteString(quoteSQL(token.Text))
+			lossy(offset, token, "no template pattern")
+		}
+		offset += len(token.Text)
+	}
+	return out.String(), diags
+}
+
+// quoteSQL quotes text that has characters other than spaces and the
+// punctuation Oracle allows outside quotes.
+func quoteSQL(text string) string {
+	for _, r := range text {
+		if !strings.ContainsRune(" -/,.;:", r) {
+			return `"` + strings.ReplaceAll(strings.ReplaceAll(text, `\`, `\\`), `"`, `\"`) + `"`
+		}
+	}
+	return text
+}
+
+// mysql maps each DATE_FORMAT specifier to the token it formats as, with a
+// message if the token is not exact. Composites are expanded, a zero token
+// means there is none.
+var mysql = map[byte]struct {
+	token   Token
+	message string
+}{
+	'a': {Token{WeekDay, "Mon"}, ""},
+	'b': {Token{Month, "Jan"}, ""},
+	'c': {Token{NumMonth, "1"}, ""},
+	'D': {Token{OrdinalDay, "{2nd}"}, ""},
+	'd': {Token{ZeroDay, "02"}, ""},
+	'e': {Token{Day, "2"}, ""},
+	'f': {Token{Microseconds, "{frac6}"}, ""},
+	'H': {Token{Hour, "15"}, ""},
+	'h': {Token{ZeroHour12, "03"}, ""},
+	'I': {Token{ZeroHour12, "03"}, ""},
+	'i': {Token{ZeroMinute, "04"}, ""},
+	'j': {Token{ZeroYearDay, "002"}, ""},
+	'k': {Token{Hour, "15"}, "no layout token for an unpadded 24-hour clock"},
+	'l': {Token{Hour12, "3"}, ""},
+	'M': {Token{LongMonth, "January"}, ""},
+	'm': {Token{ZeroMonth, "01"}, ""},
+	'p': {Token{PM, "PM"}, ""},
+	'S': {Token{ZeroSecond, "05"}, ""},
+	's': {Token{ZeroSecond, "05"}, ""},
+	'U': {Token{SundayWeek, "{sunweek}"}, ""},
+	'u': {Token{MondayWeek, "{monweek}"}, "MySQL counts the first week with four days as week 1"},
+	'V': {Token{SundayWeek, "{sunweek}"}, "MySQL counts weeks from 1 in the year of %X"},
+	'v': {Token{ISOWeek, "{isoweek}"}, ""},
+	'W': {Token{LongWeekDay, "Monday"}, ""},
+	'w': {Token{WeekDayNum, "{weekday0}"}, ""},
+	'X': {Token{ISOYear, "{isoyear}"}, "MySQL weeks of %X start on Sunday"},
+	'x': {Token{ISOYear, "{isoyear}"}, ""},
+	'Y': {Token{LongYear, "2006"}, ""},
+	'y': {Token{Year, "06"}, ""},
+}
+
+// mysqlComposite holds the specifiers that stand for several others.
+var mysqlComposite = map[byte]string{
+	'r': "%h:%i:%s %p",
+	'T': "%H:%i:%s",
+}
+
+// FromMySQL translates a MySQL DATE_FORMAT string into a layout. As in
+// MySQL, a '%' before any other character stands for that character.
+func FromMySQL(format string) (string, []Diagnostic) {
+	var b builder
+	fromMySQL(&b, format, -1)
+	return b.layout()
+}
+
+// fromMySQL adds the tokens of format to b, with all offsets set to at for
+// an expanded composite.
+func fromMySQL(b *builder, format string, at int) {
+	for i := 0; i < len(format); i++ {
+		offset := at
+		if at < 0 {
+			offset = i
+		}
+		if format[i] != '%' || i+1 == len(format) {
+			b.literal(offset, format[i:i+1])
+			continue
+		}
+		i++
+		c := format[i]
+		if composite, ok := mysqlComposite[c]; ok {
+			fromMySQL(b, composite, offset)
+			continue
+		}
+		spec, ok := mysql[c]
+		if !ok {
+			b.literal(offset, format[i:i+1])
+			continue
+		}
+		if spec.message != "" {
+			b.lossy(offset, format[i-1:i+1], spec.message)
+		}
+		if c == 'f' && b.fraction(offset, FracSecond0, 6) != 0 {
+			continue
+		}
+		b.token(offset, spec.token.Kind, spec.token.Text)
+	}
+}
+
+// ToMySQL translates a layout into a MySQL DATE_FORMAT string. DATE_FORMAT
+// has no zones, so zone tokens are reported and left out.
+func ToMySQL(layout string) (string, []Diagnostic) {
+	var out strings.Builder
+	var diags []Diagnostic
+	lossy := func(offset int, token Token, message string) {
+		diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: message})
+	}
+	offset := 0
+	for _, token := range Tokenize(layout) {
+		switch token.Kind {
+		case Literal:
+			out.WriteString(strings.ReplaceAll(token.Text, "%", "%%"))
+		case LongYear:
+			out.WriteString("%Y")
+		case Year:
+			out.WriteString("%y")
+		case LongMonth:
+			out.WriteString("%M")
+		case Month:
+			out.WriteString("%b")
+		case NumMonth:
+			out.WriteString("%c")
+		case ZeroMonth:
+			out.WriteString("%m")
+		case LongWeekDay:
+			out.WriteString("%W")
+		case WeekDay:
+			out.WriteString("%a")
+		case Day:
+			out.WriteString("%e")
+		case ZeroDay:
+			out.WriteString("%d")
+		case OrdinalDay:
+			out.WriteString("%D")
+		case ZeroYearDay:
+			out.WriteString("%j")
+		case Hour:
+			out.WriteString("%H")
+		case Hour12:
+			out.WriteString("%l")
+		case ZeroHour12:
+			out.WriteString("%h")
+		case ZeroMinute:
+			out.WriteString("%i")
+		case ZeroSecond:
+			out.WriteString("%s")
+		case PM:
+			out.WriteString("%p")
+		case Microseconds:
+			out.WriteString("%f")
+		case FracSecond0, FracSecond9:
+			out.WriteByte(token.Separator())
+			out.WriteString("%f")
+			if token.Kind == FracSecond9 || token.Digits() != 6 {
+				lossy(offset, token, "MySQL only has six-digit fractions")
+			}
+		case Milliseconds, Nanoseconds:
+			out.WriteString("%f")
+			lossy(offset, token, "MySQL only has six-digit fractions")
+		case SundayWeek:
+			out.WriteString("%U")
+		case ISOWeek:
+			out.WriteString("%v")
+		case ISOYear:
+			out.WriteString("%x")
+		case WeekDayNum:
+			out.WriteString("%w")
+		case Minute:
+			out.WriteString("%i")
+			lossy(offset, token, "MySQL always pads minutes")
+		case Second:
+			out.WriteString("%s")
+			lossy(offset, token, "MySQL always pads seconds")
+		case LowerPM:
+			out.WriteString("%p")
+			lossy(offset, token, "MySQL has no lower-case AM/PM")
+		case UnderDay, UnderHour, UnderHour12:
+			out.WriteString(map[Kind]string{UnderDay: "%e", UnderHour: "%k", UnderHour12: "%l"}[token.Kind])
+			lossy(offset, token, "MySQL cannot pad with spaces")
+		case TZ, ISO8601TZ, ISO8601SecondsTZ, ISO8601ShortTZ, ISO8601ColonTZ, ISO8601ColonSecondsTZ,
+			NumTZ, NumSecondsTZ, NumShortTZ, NumColonTZ, NumColonSecondsTZ:
+			lossy(offset, token, "DATE_FORMAT has no zones")
+		default:
+			out.WriteString(strings.ReplaceAll(token.Text, "%", "%%"))
+			lossy(offset, token, "no DATE_FORMAT specifier")
+		}
+		offset += len(token.Text)
+	}
+	return out.String(), diags
+}
@@ -0,0 +1,123 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromPostgres(t *testing.T) {
+	testData := []struct {
+		Template string
+		Layout   string
+		Lossy    bool
+	}{
+		{Template: "YYYY-MM-DD HH24:MI:SS", Layout: "2006-01-02 15:04:05"},
+		{Template: "YYYY-MM-DD\"T\"HH24:MI:SS.US TZH:TZM", Layout: "2006-01-02T15:04:05.000000 -07:00"},
+		{Template: "Dy, DD Mon YYYY HH12:MI:SS AM TZ", Layout: "Mon, 02 Jan 2006 03:04:05 PM MST"},
+		{Template: "FMDay, FMMonth FMDDth", Layout: "Monday, January {2nd}"},
+		{Template: "FMMM/FMDD FMHH12:MI pm", Layout: "1/2 3:04 pm"},
+		{Template: "IYYY-\"W\"IW-ID", Layout: "{isoyear}-W{isoweek}-{weekday}"},
+		{Template: "hh24:mi:ss,ms", Layout: "15:04:05,000"},
+		{Template: "HH24MISSFF3", Layout: "150405{frac3}"},
+		{Template: "\"at \\\"noon\\\"\"", Layout: `at "noon"`},
+		{Template: "Month DD", Layout: "January 02", Lossy: true},
+		{Template: "MON DD", Layout: "Jan 02", Lossy: true},
+		{Template: "DDth", Layout: "02", Lossy: true},
+		{Template: "FMHH24", Layout: "15", Lossy: true},
+		{Template: "CC", Layout: "{century}", Lossy: true},
+		{Template: "Q YYYY", Layout: "Q 2006", Lossy: true},
+		{Template: "FMHH24 A.M.", Layout: "15 PM", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromPostgres(test.Template)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromPostgres %q\nwant=%q lossy=%v\ngot= %q %v", test.Template, test.Layout, test.Lossy, layout, diags)
+		}
+	}
+}
+
+func TestFromOracle(t *testing.T) {
+	testData := []struct {
+		Mask   string
+		Layout string
+		Lossy  bool
+	}{
+		{Mask: "YYYY-MM-DD HH24:MI:SS", Layout: "2006-01-02 15:04:05"},
+		{Mask: "DD-MON-RR", Layout: "02-Jan-06", Lossy: true},
+		{Mask: "DD-Mon-RR HH.MI.SSXFF AM", Layout: "02-Jan-06 03.04.05.000000 PM", Lossy: true},
+		{Mask: "YYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM", Layout: "2006-01-02 15:04:05.000000000 -07:00"},
+		{Mask: "fmMonth DD, YYYY", Layout: "January 2, 2006"},
+		{Mask: "fmMonth fmDD, YYYY", Layout: "January 02, 2006"},
+		{Mask: "fmDDth \"of\" Month", Layout: "{2nd} of January"},
+		{Mask: "HH24:MI TZD", Layout: "15:04 MST"},
+		{Mask: "HH24:MI TZR", Layout: "15:04 MST", Lossy: true},
+		{Mask: "DL", Layout: "DL", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromOracle(test.Mask)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromOracle %q\nwant=%q lossy=%v\ngot= %q %v", test.Mask, test.Layout, test.Lossy, layout, diags)
+		}
+	}
+}
+
+func TestToSQL(t *testing.T) {
+	testData := []struct {
+		Layout   string
+		Postgres string
+		Oracle   string
+		Lossy    bool
+	}{
+		{Layout: "2006-01-02 15:04:05", Postgres: "YYYY-MM-DD HH24:MI:SS", Oracle: "YYYY-MM-DD HH24:MI:SS"},
+		{Layout: "Monday, January {2nd} 2006", Postgres: "FMDay, FMMonth FMDDth YYYY", Oracle: "FMDay, Month DDth YYYY"},
+		{Layout: "January 2 15:04", Postgres: "FMMonth FMDD HH24:MI", Oracle: "FMMonth DD FMHH24:MI"},
+		{Layout: "2006-01-02T15:04:05.000000-07:00", Postgres: "YYYY-MM-DD\"T\"HH24:MI:SS.FF6TZH:TZM", Oracle: "YYYY-MM-DD\"T\"HH24:MI:SS.FF6TZH:TZM"},
+		{Layout: "3:04 pm MST", Postgres: "FMHH12:MI am TZ", Oracle: "FMHH12:FMMI am TZD"},
+		{Layout: time.RFC3339Nano, Postgres: "YYYY-MM-DD\"T\"HH24:MI:SS.FF6TZH:TZM", Oracle: "YYYY-MM-DD\"T\"HH24:MI:SS.FF9TZH:TZM", Lossy: true},
+		{Layout: "{century}", Postgres: "CC", Oracle: "CC", Lossy: true},
+	}
+	for _, test := range testData {
+		template, diags := ToPostgres(test.Layout)
+		if template != test.Postgres || (len(diags) > 0) != test.Lossy {
+			t.Errorf("ToPostgres %q\nwant=%q lossy=%v\ngot= %q %v", test.Layout, test.Postgres, test.Lossy, template, diags)
+		}
+		mask, diags := ToOracle(test.Layout)
+		if mask != test.Oracle || (len(diags) > 0) != test.Lossy {
+			t.Errorf("ToOracle %q\nwant=%q lossy=%v\ngot= %q %v", test.Layout, test.Oracle, test.Lossy, mask, diags)
+		}
+	}
+}
+
+func TestMySQL(t *testing.T) {
+	testData := []struct {
+		MySQL  string
+		Layout string
+		Lossy  bool
+	}{
+		{MySQL: "%Y-%m-%d %H:%i:%s", Layout: "2006-01-02 15:04:05"},
+		{MySQL: "%W, %M %D %Y", Layout: "Monday, January {2nd} 2006"},
+		{MySQL: "%a %b %e %l:%i %p", Layout: "Mon Jan 2 3:04 PM"},
+		{MySQL: "%T.%f", Layout: "15:04:05.000000"},
+		{MySQL: "%x-W%v-%w %j %%", Layout: "{isoyear}-W{isoweek}-{weekday0} 002 %"},
+		{MySQL: "%r", Layout: "03:04:05 PM"},
+		{MySQL: "%k:%i", Layout: "15:04", Lossy: true},
+		{MySQL: "%u %q", Layout: "{monweek} q", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromMySQL(test.MySQL)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromMySQL %q\nwant=%q lossy=%v\ngot= %q %v", test.MySQL, test.Layout, test.Lossy, layout, diags)
+			continue
+		}
+		if test.Lossy || test.MySQL == "%r" || test.MySQL == "%T.%f" {
+			continue
+		}
+		if format, diags := ToMySQL(test.Layout); format != test.MySQL || len(diags) > 0 {
+			t.Errorf("ToMySQL %q\nwant=%q\ngot= %q %v", test.Layout, test.MySQL, format, diags)
+		}
+	}
+
+	if format, diags := ToMySQL(time.RFC3339); format != "%Y-%m-%dT%H:%i:%s" || len(diags) != 1 {
+		t.Errorf("ToMySQL %q = %q %v, want the zone reported and left out", time.RFC3339, format, diags)
+	}
+}
@@ -0,0 +1,314 @@
+package timeformat
+
+import "strings"
+
+// strftime maps each conversion character to the token it formats as with
+// no flags and no width, together with that default width.
+var strftime = map[byte]struct {
+	token Token
+	width int
+}{
+	'Y': {Token{LongYear, "2006"}, 4},
+	'y': {Token{Year, "06"}, 2},
+	'C': {Token{Century, "{century}"}, 2},
+	'G': {Token{ISOYear, "{isoyear}"}, 4},
+	'g': {Token{ShortISOYear, "{isoyear2}"}, 2},
+	'm': {Token{ZeroMonth, "01"}, 2},
+	'B': {Token{LongMonth, "January"}, 0},
+	'b': {Token{Month, "Jan"}, 0},
+	'h': {Token{Month, "Jan"}, 0},
+	'A': {Token{LongWeekDay, "Monday"}, 0},
+	'a': {Token{WeekDay, "Mon"}, 0},
+	'u': {Token{ISOWeekDay, "{weekday}"}, 1},
+	'w': {Token{WeekDayNum, "{weekday0}"}, 1},
+	'V': {Token{ISOWeek, "{isoweek}"}, 2},
+	'U': {Token{SundayWeek, "{sunweek}"}, 2},
+	'W': {Token{MondayWeek, "{monweek}"}, 2},
+	'd': {Token{ZeroDay, "02"}, 2},
+	'e': {Token{UnderDay, "_2"}, 2},
+	'j': {Token{ZeroYearDay, "002"}, 3},
+	'H': {Token{Hour, "15"}, 2},
+	'k': {Token{UnderHour, "{_15}"}, 2},
+	'I': {Token{ZeroHour12, "03"}, 2},
+	'l': {Token{UnderHour12, "{_3}"}, 2},
+	'M': {Token{ZeroMinute, "04"}, 2},
+	'S': {Token{ZeroSecond, "05"}, 2},
+	'p': {Token{PM, "PM"}, 0},
+	'P': {Token{LowerPM, "pm"}, 0},
+	's': {Token{Unix, "{unix}"}, 1},
+	'f': {Token{Microseconds, "{frac6}"}, 6},
+	'Z': {Token{TZ, "MST"}, 0},
+	'z': {Token{NumTZ, "-0700"}, 0},
+}
+
+// strftimePadded gives the token a numeric conversion formats as with the
+// '-' (no padding), '_' (spaces) and '0' (zeros) flags. Missing entries have
+// no Go equivalent.
+var strftimePadded = map[byte][3]Token{
+	'd': {{Day, "2"}, {UnderDay, "_2"}, {ZeroDay, "02"}},
+	'e': {{Day, "2"}, {UnderDay, "_2"}, {ZeroDay, "02"}},
+	'm': {{NumMonth, "1"}, {}, {ZeroMonth, "01"}},
+	'j': {{}, {UnderYearDay, "__2"}, {ZeroYearDay, "002"}},
+	'H': {{}, {UnderHour, "{_15}"}, {Hour, "15"}},
+	'k': {{}, {UnderHour, "{_15}"}, {Hour, "15"}},
+	'I': {{Hour12, "3"}, {UnderHour12, "{_3}"}, {ZeroHour12, "03"}},
+	'l': {{Hour12, "3"}, {UnderHour12, "{_3}"}, {ZeroHour12, "03"}},
+	'M': {{Minute, "4"}, {}, {ZeroMinute, "04"}},
+	'S': {{Second, "5"}, {}, {ZeroSecond, "05"}},
+}
+
+// strftimeComposite holds the conversions that stand for several others.
+var strftimeComposite = map[byte]string{
+	'c': "%a %b %e %H:%M:%S %Y",
+	'+': "%a %b %e %H:%M:%S %Z %Y",
+	'F': "%Y-%m-%d",
+	'D': "%m/%d/%y",
+	'x': "%m/%d/%y",
+	'v': "%e-%b-%Y",
+	'T': "%H:%M:%S",
+	'X': "%H:%M:%S",
+	'r': "%I:%M:%S %p",
+	'R': "%H:%M",
+}
+
+// FromStrftime translates a strftime format, as timefmt-go and glibc
+// understand it, into a layout. Conversions with no exact counterpart are
+// reported and translated to the closest token, or kept as literal text.
+func FromStrftime(format string) (string, []Diagnostic) {
+	var b builder
+	fromStrftime(&b, format, -1)
+	return b.layout()
+}
+
+// fromStrftime adds the tokens of format to b. Composites are expanded with
+// all offsets set to the position of the composite in the outer format.
+func fromStrftime(b *builder, format string, at int) {
+	for i := 0; i < len(format); i++ {
+		offset := at
+		if at < 0 {
+			offset = i
+		}
+		if format[i] != '%' {
+			b.literal(offset, format[i:i+1])
+			continue
+		}
+		j := i + 1
+		for j < len(format) && strings.IndexByte("-_0^#", format[j]) >= 0 {
+			j++
+		}
+		flags := format[i+1 : j]
+		width := 0
+		for j < len(format) && '0' <= format[j] && format[j] <= '9' {
+			width = width*10 + int(format[j]-'0')
+			j++
+		}
+		colons := 0
+		for j < len(format) && format[j] == ':' {
+			colons++
+			j++
+		}
+		if j == len(format) {
+			b.literal(offset, format[i:])
+			if j > i+1 {
+				b.lossy(offset, format[i:], "incomplete conversion")
+			}
+			return
+		}
+		directive := format[i : j+1]
+		c := format[j]
+		i = j
+		if colons > 0 && c != 'z' {
+			b.literal(offset, directive)
+			b.lossy(offset, directive, "unknown conversion")
+			continue
+		}
+		switch c {
+		case '%':
+			b.literal(offset, "%")
+			continue
+		case 't':
+			b.literal(offset, "\t")
+			continue
+		case 'n':
+			b.literal(offset, "\n")
+			continue
+		}
+		if composite, ok := strftimeComposite[c]; ok {
+			if flags != "" || width != 0 {
+				b.lossy(offset, directive, "flags and width on a composite conversion are ignored")
+			}
+			fromStrftime(b, composite, offset)
+			continue
+		}
+		conv, ok := strftime[c]
+		if !ok {
+			b.literal(offset, directive)
+			b.lossy(offset, directive, "unknown conversion")
+			continue
+		}
+		token := conv.token
+		if c == 'z' {
+			switch colons {
+			case 0:
+			case 1:
+				token = Token{NumColonTZ, "-07:00"}
+			case 2:
+				token = Token{NumColonSecondsTZ, "-07:00:00"}
+			default:
+				token = Token{NumColonTZ, "-07:00"}
+				b.lossy(offset, directive, "no layout token for minimal-precision offsets")
+			}
+		}
+		for _, flag := range []byte(flags) {
+			switch flag {
+			case '-', '_', '0':
+				if padded, ok := strftimePadded[c]; ok {
+					p := padded[strings.IndexByte("-_0", flag)]
+					if p.Text == "" {
+						b.lossy(offset, directive, "no layout token with this padding")
+						break
+					}
+					token = p
+				} else if conv.width > 1 && flag != '0' {
+					b.lossy(offset, directive, "no layout token with this padding")
+				}
+			case '^':
+				if token.Kind == LowerPM {
+					token = Token{PM, "PM"}
+				} else if token.Kind != PM && conv.width == 0 {
+					b.lossy(offset, directive, "no layout token for upper-case names")
+				}
+			case '#':
+				if token.Kind == PM {
+					token = Token{LowerPM, "pm"}
+				} else if conv.width == 0 && token.Kind != LowerPM {
+					b.lossy(offset, directive, "no layout token for swapped-case names")
+				}
+			}
+		}
+		if width != 0 && width != conv.width {
+			b.lossy(offset, directive, "no layout token with this width")
+		}
+		b.token(offset, token.Kind, token.Text)
+	}
+}
+
+// ToStrftime translates a layout into a strftime format. Tokens with no
+// exact counterpart are reported and translated to the closest conversion.
+func ToStrftime(layout string) (string, []Diagnostic) {
+	var out strings.Builder
+	var diags []Diagnostic
+	offset := 0
+	for _, token := range Tokenize(layout) {
+		if token.Kind == Literal {
+			out.WriteString(strings.ReplaceAll(token.Text, "%", "%%"))
+		} else {
+			directive, message := strftimeDirective(token)
+			out.WriteString(directive)
+			if message != "" {
+				diags = append(diags, Diagnostic{Offset: offset, Text: token.Text, Message: message})
+			}
+		}
+		offset += len(token.Text)
+	}
+	return out.String(), diags
+}
+
+// strftimeDirective returns the conversion a token translates to, with a
+// message if it is not exact.
+func strftimeDirective(token Token) (directive, message string) {
+	switch token.Kind {
+	case LongYear:
+		return "%Y", ""
+	case Year:
+		return "%y", ""
+	case LongMonth:
+		return "%B", ""
+	case Month:
+		return "%b", ""
+	case NumMonth:
+		return "%-m", ""
+	case ZeroMonth:
+		return "%m", ""
+	case LongWeekDay:
+		return "%A", ""
+	case WeekDay:
+		return "%a", ""
+	case Day:
+		return "%-d", ""
+	case UnderDay:
+		return "%e", ""
+	case ZeroDay:
+		return "%d", ""
+	case OrdinalDay:
+		return "%-d", "strftime has no ordinal day"
+	case UnderYearDay:
+		return "%_j", ""
+	case ZeroYearDay:
+		return "%j", ""
+	case Hour:
+		return "%H", ""
+	case UnderHour:
+		return "%k", ""
+	case Hour12:
+		return "%-I", ""
+	case ZeroHour12:
+		return "%I", ""
+	case UnderHour12:
+		return "%l", ""
+	case Minute:
+		return "%-M", ""
+	case ZeroMinute:
+		return "%M", ""
+	case Second:
+		return "%-S", ""
+	case ZeroSecond:
+		return "%S", ""
+	case PM:
+		return "%p", ""
+	case LowerPM:
+		return "%P", ""
+	case TZ:
+		return "%Z", ""
+	case NumTZ:
+		return "%z", ""
+	case NumColonTZ:
+		return "%:z", ""
+	case NumColonSecondsTZ:
+		return "%::z", ""
+	case ISO8601TZ, ISO8601ShortTZ, ISO8601SecondsTZ, NumShortTZ, NumSecondsTZ:
+		return "%z", "strftime has no such offset form, using %z"
+	case ISO8601ColonTZ:
+		return "%:z", "strftime prints +00:00 instead of Z for UTC"
+	case ISO8601ColonSecondsTZ:
+		return "%::z", "strftime prints +00:00:00 instead of Z for UTC"
+	case FracSecond0, FracSecond9:
+		if token.Kind == FracSecond9 || token.Digits() != 6 {
+			message = "strftime only has six-digit fractions"
+		}
+		return string(token.Separator()) + "%f", message
+	case Microseconds:
+		return "%f", ""
+	case Milliseconds, Nanoseconds:
+		return "%f", "strftime only has six-digit fractions"
+	case Century:
+		return "%C", ""
+	case ISOYear:
+		return "%G", ""
+	case ShortISOYear:
+		return "%g", ""
+	case ISOWeek:
+		return "%V", ""
+	case ISOWeekDay:
+		return "%u", ""
+	case WeekDayNum:
+		return "%w", ""
+	case SundayWeek:
+		return "%U", ""
+	case MondayWeek:
+		return "%W", ""
+	case Unix:
+		return "%s", ""
+	}
+	return "", "no strftime conversion"
+}
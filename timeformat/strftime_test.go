@@ -0,0 +1,101 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+
+	timefmt "github.com/itchyny/timefmt-go"
+)
+
+func TestFromStrftime(t *testing.T) {
+	testData := []struct {
+		Strftime string
+		Layout   string
+		Lossy    bool
+	}{
+		{Strftime: "%Y-%m-%dT%H:%M:%S%z", Layout: "2006-01-02T15:04:05-0700"},
+		{Strftime: "%F %T", Layout: "2006-01-02 15:04:05"},
+		{Strftime: "%a, %d %b %Y %T %Z", Layout: "Mon, 02 Jan 2006 15:04:05 MST"},
+		{Strftime: "%-d/%-m %-I:%M %p", Layout: "2/1 3:04 PM"},
+		{Strftime: "%e %_d %0e", Layout: "_2 _2 02"},
+		{Strftime: "%k|%l|%_H|%0k", Layout: "{_15}|{_3}|{_15}|15"},
+		{Strftime: "%G-W%V-%u", Layout: "{isoyear}-W{isoweek}-{weekday}"},
+		{Strftime: "%s.%f", Layout: "{unix}.{frac6}"},
+		{Strftime: "%:z %::z", Layout: "-07:00 -07:00:00"},
+		{Strftime: "%#p %^P", Layout: "pm PM"},
+		{Strftime: "%% %t", Layout: "% \t"},
+		{Strftime: "%r", Layout: "03:04:05 PM"},
+		{Strftime: "%-H", Layout: "15", Lossy: true},
+		{Strftime: "%^B", Layout: "January", Lossy: true},
+		{Strftime: "%10Y", Layout: "2006", Lossy: true},
+		{Strftime: "%Q", Layout: "%Q", Lossy: true},
+		{Strftime: "day 1 of %Y", Layout: "day 1 of 2006", Lossy: true},
+		{Strftime: "%b%Y", Layout: "Jan2006"},
+		{Strftime: "%bu", Layout: "Janu", Lossy: true},
+		{Strftime: "%buary", Layout: "January", Lossy: true},
+	}
+	for _, test := range testData {
+		layout, diags := FromStrftime(test.Strftime)
+		if layout != test.Layout || (len(diags) > 0) != test.Lossy {
+			t.Errorf("FromStrftime %q\nwant=%q lossy=%v\ngot= %q %v", test.Strftime, test.Layout, test.Lossy, layout, diags)
+		}
+	}
+}
+
+func TestToStrftime(t *testing.T) {
+	testData := []struct {
+		Layout   string
+		Strftime string
+		Lossy    bool
+	}{
+		{Layout: time.RFC1123Z, Strftime: "%a, %d %b %Y %H:%M:%S %z"},
+		{Layout: time.Kitchen, Strftime: "%-I:%M%p"},
+		{Layout: "2006-01-02 15:04:05.000000 %", Strftime: "%Y-%m-%d %H:%M:%S.%f %%"},
+		{Layout: "__2 {_15} {_3} pm", Strftime: "%_j %k %l %P"},
+		{Layout: time.RFC3339, Strftime: "%Y-%m-%dT%H:%M:%S%:z", Lossy: true},
+		{Layout: "Jan {2nd}", Strftime: "%b %-d", Lossy: true},
+		{Layout: "15:04:05.000", Strftime: "%H:%M:%S.%f", Lossy: true},
+	}
+	for _, test := range testData {
+		format, diags := ToStrftime(test.Layout)
+		if format != test.Strftime || (len(diags) > 0) != test.Lossy {
+			t.Errorf("ToStrftime %q\nwant=%q lossy=%v\ngot= %q %v", test.Layout, test.Strftime, test.Lossy, format, diags)
+		}
+	}
+}
+
+// TestStrftimeMatchesTimefmt formats every exact translation next to
+// timefmt-go.
+func TestStrftimeMatchesTimefmt(t *testing.T) {
+	formats := []string{"%%", "%t", "%n"}
+	for c := range strftime {
+		formats = append(formats, "%"+string(c))
+	}
+	for c := range strftimeComposite {
+		formats = append(formats, "%"+string(c))
+	}
+	for c := range strftimePadded {
+		formats = append(formats, "%-"+string(c), "%_"+string(c), "%0"+string(c))
+	}
+	formats = append(formats, "%:z", "%::z", "%^p", "%#p", "%^P", "%02d", "%4Y", "%-a", "%_B")
+
+	instants := []time.Time{
+		time.Date(2021, 1, 3, 0, 5, 9, 1000, time.UTC),
+		time.Date(2020, 12, 31, 9, 0, 0, 120000000, time.FixedZone("", -9000)),
+		time.Date(1999, 6, 14, 12, 59, 59, 999999999, time.FixedZone("CEST", 7200)),
+		time.Date(2024, 2, 29, 23, 30, 1, 0, location("America/New_York")),
+		time.Date(1, 1, 1, 13, 0, 0, 0, time.UTC),
+	}
+	for _, format := range formats {
+		layout, diags := FromStrftime(format)
+		if len(diags) > 0 {
+			continue
+		}
+		for _, ts := range instants {
+			want := timefmt.Format(ts, format)
+			if got := Format(ts, layout); got != want {
+				t.Errorf("strftime %q as %q for %v\nwant=%q\ngot= %q", format, layout, ts, want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package timeformat
+
+// Kind identifies the field a layout token stands for.
+type Kind int
+
+const (
+	Literal               Kind = iota // text copied as is
+	LongYear                          // "2006"
+	Year                              // "06"
+	LongMonth                         // "January"
+	Month                             // "Jan"
+	NumMonth                          // "1"
+	ZeroMonth                         // "01"
+	LongWeekDay                       // "Monday"
+	WeekDay                           // "Mon"
+	Day                               // "2"
+	UnderDay                          // "_2"
+	ZeroDay                           // "02"
+	UnderYearDay                      // "__2"
+	ZeroYearDay                       // "002"
+	Hour                              // "15"
+	Hour12                            // "3"
+	ZeroHour12                        // "03"
+	Minute                            // "4"
+	ZeroMinute                        // "04"
+	Second                            // "5"
+	ZeroSecond                        // "05"
+	PM                                // "PM"
+	LowerPM                           // "pm"
+	TZ                                // "MST"
+	ISO8601TZ                         // "Z0700", prints Z for UTC
+	ISO8601SecondsTZ                  // "Z070000"
+	ISO8601ShortTZ                    // "Z07"
+	ISO8601ColonTZ                    // "Z07:00", prints Z for UTC
+	ISO8601ColonSecondsTZ             // "Z07:00:00"
+	NumTZ                             // "-0700", always numeric
+	NumSecondsTZ                      // "-070000"
+	NumShortTZ                        // "-07"
+	NumColonTZ                        // "-07:00"
+	NumColonSecondsTZ                 // "-07:00:00"
+	FracSecond0                       // ".0", ".00", ... trailing zeros included
+	FracSecond9                       // ".9", ".99", ... trailing zeros omitted
+
+	// Extended tokens are written in braces and have no Go layout equivalent.
+	OrdinalDay   // "{2nd}", day of month as a locale ordinal
+	UnderHour    // "{_15}", 24-hour clock, space-padded
+	UnderHour12  // "{_3}", 12-hour clock, space-padded
+	Century      // "{century}", year / 100
+	ISOYear      // "{isoyear}", year of the ISO 8601 week
+	ShortISOYear // "{isoyear2}"
+	ISOWeek      // "{isoweek}", 01-53
+	ISOWeekDay   // "{weekday}", 1-7 from Monday
+	WeekDayNum   // "{weekday0}", 0-6 from Sunday
+	SundayWeek   // "{sunweek}", 00-53, weeks starting on Sunday
+	MondayWeek   // "{monweek}", 00-53, weeks starting on Monday
+	Unix         // "{unix}", seconds since 1970-01-01 UTC
+	Milliseconds // "{frac3}", fraction digits without separator
+	Microseconds // "{frac6}"
+	Nanoseconds  // "{frac9}"
+)
+
+// extended maps the spelling of each extended token to its kind.
+var extended = map[string]Kind{
+	"{2nd}":      OrdinalDay,
+	"{_15}":      UnderHour,
+	"{_3}":       UnderHour12,
+	"{century}":  Century,
+	"{isoyear}":  ISOYear,
+	"{isoyear2}": ShortISOYear,
+	"{isoweek}":  ISOWeek,
+	"{weekday}":  ISOWeekDay,
+	"{weekday0}": WeekDayNum,
+	"{sunweek}":  SundayWeek,
+	"{monweek}":  MondayWeek,
+	"{unix}":     Unix,
+	"{frac3}":    Milliseconds,
+	"{frac6}":    Microseconds,
+	"{frac9}":    Nanoseconds,
+}
+
+// Token is one element of a layout.
+type Token struct {
+	Kind Kind
+	Text string // the token as written in the layout
+}
+
+// Digits returns the number of fraction digits of a FracSecond token.
+func (t Token) Digits() int {
+	if t.Kind != FracSecond0 && t.Kind != FracSecond9 {
+		return 0
+	}
+	return len(t.Text) - 1
+}
+
+// Separator returns the '.' or ',' leading a FracSecond token.
+func (t Token) Separator() byte {
+	if t.Kind != FracSecond0 && t.Kind != FracSecond9 {
+		return 0
+	}
+	return t.Text[0]
+}
+
+// Tokenize splits a layout into tokens using the same rules as the time
+// package, plus the extended tokens in braces. Braces that do not spell an
+// extended token are literal text. Adjacent literal text is merged into a
+// single Literal token.
+func Tokenize(layout string) []Token {
+	var tokens []Token
+	for layout != "" {
+		prefix, kind, text, suffix := nextChunk(layout)
+		if prefix != "" {
+			if n := len(tokens); n > 0 && tokens[n-1].Kind == Literal {
+				tokens[n-1].Text += prefix
+			} else {
+				tokens = append(tokens, Token{Kind: Literal, Text: prefix})
+			}
+		}
+		if text == "" {
+			break
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: text})
+		layout = suffix
+	}
+	return tokens
+}
+
+// nextChunk mirrors nextStdChunk from the time package: it returns the text
+// before the first token, the token kind and text, and the rest of the layout.
+// An empty token text means no token was found.
+func nextChunk(layout string) (prefix string, kind Kind, text, suffix string) {
+	for i := 0; i < len(layout); i++ {
+		chunk := func(k Kind, n int) (string, Kind, string, string) {
+			return layout[:i], k, layout[i : i+n], layout[i+n:]
+		}
+		has := func(s string) bool {
+			return len(layout) >= i+len(s) && layout[i:i+len(s)] == s
+		}
+		switch c := layout[i]; c {
+		case 'J': // January, Jan
+			if has("Jan") {
+				if has("January") {
+					return chunk(LongMonth, 7)
+				}
+				if !startsWithLowerCase(layout[i+3:]) {
+					return chunk(Month, 3)
+				}
+			}
+		case 'M': // Monday, Mon, MST
+			if has("Mon") {
+				if has("Monday") {
+					return chunk(LongWeekDay, 6)
+				}
+				if !startsWithLowerCase(layout[i+3:]) {
+					return chunk(WeekDay, 3)
+				}
+			}
+			if has("MST") {
+				return chunk(TZ, 3)
+			}
+		case '0': // 01, 02, 03, 04, 05, 06, 002
+			if len(layout) >= i+2 && '1' <= layout[i+1] && layout[i+1] <= '6' {
+				return chunk(zeroKinds[layout[i+1]-'1'], 2)
+			}
+			if has("002") {
+				return chunk(ZeroYearDay, 3)
+			}
+		case '1': // 15, 1
+			if has("15") {
+				return chunk(Hour, 2)
+			}
+			return chunk(NumMonth, 1)
+		case '2': // 2006, 2
+			if has("2006") {
+				return chunk(LongYear, 4)
+			}
+			return chunk(Day, 1)
+		case '_': // _2, _2006, __2
+			if has("_2") {
+				// _2006 is really a literal _, followed by LongYear
+				if has("_2006") {
+					return layout[:i+1], LongYear, layout[i+1 : i+5], layout[i+5:]
+				}
+				return chunk(UnderDay, 2)
+			}
+			if has("__2") {
+				return chunk(UnderYearDay, 3)
+			}
+		case '3':
+			return chunk(Hour12, 1)
+		case '4':
+			return chunk(Minute, 1)
+		case '5':
+			return chunk(Second, 1)
+		case 'P': // PM
+			if has("PM") {
+				return chunk(PM, 2)
+			}
+		case 'p': // pm
+			if has("pm") {
+				return chunk(LowerPM, 2)
+			}
+		case '-': // -070000, -07:00:00, -0700, -07:00, -07
+			switch {
+			case has("-070000"):
+				return chunk(NumSecondsTZ, 7)
+			case has("-07:00:00"):
+				return chunk(NumColonSecondsTZ, 9)
+			case has("-0700"):
+				return chunk(NumTZ, 5)
+			case has("-07:00"):
+				return chunk(NumColonTZ, 6)
+			case has("-07"):
+				return chunk(NumShortTZ, 3)
+			}
+		case 'Z': // Z070000, Z07:00:00, Z0700, Z07:00, Z07
+			switch {
+			case has("Z070000"):
+				return chunk(ISO8601SecondsTZ, 7)
+			case has("Z07:00:00"):
+				return chunk(ISO8601ColonSecondsTZ, 9)
+			case has("Z0700"):
+				return chunk(ISO8601TZ, 5)
+			case has("Z07:00"):
+				return chunk(ISO8601ColonTZ, 6)
+			case has("Z07"):
+				return chunk(ISO8601ShortTZ, 3)
+			}
+		case '{': // extended tokens
+			for j := i + 1; j < len(layout) && j-i < maxExtended; j++ {
+				if layout[j] == '}' {
+					if kind, ok := extended[layout[i:j+1]]; ok {
+						return chunk(kind, j+1-i)
+					}
+					break
+				}
+			}
+		case '.', ',': // .000, ,000, .999, ,999 - repeated digits for fractional seconds
+			if i+1 < len(layout) && (layout[i+1] == '0' || layout[i+1] == '9') {
+				ch := layout[i+1]
+				j := i + 1
+				for j < len(layout) && layout[j] == ch {
+					j++
+				}
+				// the run of digits must end here to be a fractional second
+				if j == len(layout) || layout[j] < '0' || '9' < layout[j] {
+					if ch == '0' {
+						return chunk(FracSecond0, j-i)
+					}
+					return chunk(FracSecond9, j-i)
+				}
+			}
+		}
+	}
+	return layout, Literal, "", ""
+}
+
+// maxExtended bounds the search for the closing brace of an extended token.
+const maxExtended = 16
+
+var zeroKinds = [...]Kind{ZeroMonth, ZeroDay, ZeroHour12, ZeroMinute, ZeroSecond, Year}
+
+// startsWithLowerCase prevents matching "Month" when looking for "Mon".
+func startsWithLowerCase(s string) bool {
+	return s != "" && 'a' <= s[0] && s[0] <= 'z'
+}
@@ -0,0 +1,47 @@
+package timeformat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Want   []Token
+	}{
+		{
+			Layout: "03:4:5.999999999 pm 06",
+			Want: []Token{
+				{ZeroHour12, "03"}, {Literal, ":"}, {Minute, "4"}, {Literal, ":"}, {Second, "5"},
+				{FracSecond9, ".999999999"}, {Literal, " "}, {LowerPM, "pm"}, {Literal, " "}, {Year, "06"},
+			},
+		},
+		{
+			Layout: "Month Monday Mon MST",
+			Want: []Token{
+				{Literal, "Month "}, {LongWeekDay, "Monday"}, {Literal, " "}, {WeekDay, "Mon"}, {Literal, " "}, {TZ, "MST"},
+			},
+		},
+		{
+			Layout: "_2006 _2 __2 002",
+			Want: []Token{
+				{Literal, "_"}, {LongYear, "2006"}, {Literal, " "}, {UnderDay, "_2"}, {Literal, " "},
+				{UnderYearDay, "__2"}, {Literal, " "}, {ZeroYearDay, "002"},
+			},
+		},
+		{
+			Layout: "Z07:00:00 -070000 ,000 .90000",
+			Want: []Token{
+				{ISO8601ColonSecondsTZ, "Z07:00:00"}, {Literal, " "}, {NumSecondsTZ, "-070000"}, {Literal, " "},
+				{FracSecond0, ",000"}, {Literal, " .90000"},
+			},
+		},
+	}
+	for _, test := range testData {
+		got := Tokenize(test.Layout)
+		if !reflect.DeepEqual(got, test.Want) {
+			t.Errorf("Tokenize layout=%s\nwant=%v\ngot= %v", test.Layout, test.Want, got)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package timeparse
+
+import (
+	"time"
+
+	"timeformattest/timeformat"
+)
+
+func init() {
+	timeformat.RegisterBackend(backend{})
+}
+
+// backend is the compiled engine of this module as a timeformat.Backend:
+// timeformat formats and this package parses.
+type backend struct{}
+
+func (backend) Name() string { return "compiled" }
+
+func (backend) Format(t time.Time, layout string) (string, error) {
+	return timeformat.Format(t, layout), nil
+}
+
+func (backend) Parse(layout, s string) (time.Time, error) {
+	return Parse(layout, s)
+}
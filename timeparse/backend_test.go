@@ -0,0 +1,19 @@
+package timeparse
+
+import (
+	"testing"
+
+	"timeformattest/timeformat"
+)
+
+func TestBackendMatchesStdlib(t *testing.T) {
+	compiled, stdlib := timeformat.LookupBackend("compiled"), timeformat.LookupBackend("go")
+	if compiled == nil {
+		t.Fatal("compiled backend not registered")
+	}
+	for _, n := range timeformat.Registry() {
+		for _, d := range timeformat.Differ(n.Layout, stdlib, compiled) {
+			t.Errorf("%s: %s", n.Name, d)
+		}
+	}
+}
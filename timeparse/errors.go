@@ -0,0 +1,214 @@
+package timeparse
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"timeformattest/timeformat"
+)
+
+// ParseError describes where and why a value did not match a layout.
+type ParseError struct {
+	Layout   string
+	Value    string
+	Offset   int    // byte offset in Value where matching failed
+	Token    string // layout element being matched, empty after the last one
+	Expected string // what Token accepts, for example "two-digit month 01-12"
+	Found    string // the text at Offset that was rejected, empty at the end of Value
+	Message  string // set when the text had the right form but a bad value, for example "month out of range"
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	b.WriteString("parsing time ")
+	b.WriteString(strconv.Quote(e.Value))
+	b.WriteString(" as ")
+	b.WriteString(strconv.Quote(e.Layout))
+	b.WriteString(" at offset ")
+	b.WriteString(strconv.Itoa(e.Offset))
+	b.WriteString(": ")
+	if e.Message != "" {
+		b.WriteString(e.Message)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.describe())
+	return b.String()
+}
+
+// describe returns the "expected ..., found ..." part of the message.
+func (e *ParseError) describe() string {
+	found := "end of input"
+	if e.Found != "" {
+		found = strconv.Quote(e.Found)
+	}
+	return "expected " + e.Expected + ", found " + found
+}
+
+// Snippet renders the value with a caret line under the rejected text,
+// followed by what was expected:
+//
+//	2021 13 24
+//	     ^^
+//	month out of range: expected two-digit month 01-12, found "13"
+//
+// Long values are cut to a window around the offset.
+func (e *ParseError) Snippet() string {
+	const context = 32
+	start, end := 0, len(e.Value)
+	prefix, suffix := "", ""
+	if e.Offset > context {
+		start = e.Offset - context
+		for start < e.Offset && !utf8.RuneStart(e.Value[start]) {
+			start++
+		}
+		prefix = "..."
+	}
+	if end-e.Offset-len(e.Found) > context {
+		end = e.Offset + len(e.Found) + context
+		for end > e.Offset && end < len(e.Value) && !utf8.RuneStart(e.Value[end]) {
+			end--
+		}
+		suffix = "..."
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	b.WriteString(e.Value[start:end])
+	b.WriteString(suffix)
+	b.WriteByte('\n')
+	b.WriteString(strings.Repeat(" ", len(prefix)))
+	for _, r := range e.Value[start:e.Offset] {
+		if r == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	b.WriteString(strings.Repeat("^", max(1, utf8.RuneCountInString(e.Found))))
+	b.WriteByte('\n')
+	if e.Message != "" {
+		b.WriteString(e.Message)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.describe())
+	return b.String()
+}
+
+// expectation describes in words what a layout token accepts.
+func expectation(token timeformat.Token) string {
+	switch token.Kind {
+	case timeformat.Literal:
+		if token.Text == "" {
+			return "end of input"
+		}
+		return strconv.Quote(token.Text)
+	case timeformat.LongYear:
+		return "four-digit year"
+	case timeformat.Year:
+		return "two-digit year"
+	case timeformat.LongMonth:
+		return "month name such as January"
+	case timeformat.Month:
+		return "month abbreviation such as Jan"
+	case timeformat.NumMonth:
+		return "month 1-12"
+	case timeformat.ZeroMonth:
+		return "two-digit month 01-12"
+	case timeformat.LongWeekDay:
+		return "weekday name such as Monday"
+	case timeformat.WeekDay:
+		return "weekday abbreviation such as Mon"
+	case timeformat.Day:
+		return "day of month 1-31"
+	case timeformat.UnderDay:
+		return "space-padded day of month 1-31"
+	case timeformat.ZeroDay:
+		return "two-digit day of month 01-31"
+	case timeformat.OrdinalDay:
+		return "ordinal day of month"
+	case timeformat.UnderYearDay:
+		return "space-padded day of year 1-366"
+	case timeformat.ZeroYearDay:
+		return "three-digit day of year 001-366"
+	case timeformat.Hour:
+		return "hour 00-23"
+	case timeformat.UnderHour:
+		return "space-padded hour 0-23"
+	case timeformat.Hour12:
+		return "hour 1-12"
+	case timeformat.ZeroHour12:
+		return "two-digit hour 01-12"
+	case timeformat.UnderHour12:
+		return "space-padded hour 1-12"
+	case timeformat.Minute:
+		return "minute 0-59"
+	case timeformat.ZeroMinute:
+		return "two-digit minute 00-59"
+	case timeformat.Second:
+		return "second 0-59"
+	case timeformat.ZeroSecond:
+		return "two-digit second 00-59"
+	case timeformat.PM:
+		return "AM or PM"
+	case timeformat.LowerPM:
+		return "am or pm"
+	case timeformat.TZ:
+		return "zone abbreviation such as MST"
+	case timeformat.ISO8601TZ:
+		return "Z or zone offset such as -0700"
+	case timeformat.ISO8601SecondsTZ:
+		return "Z or zone offset such as -070000"
+	case timeformat.ISO8601ShortTZ:
+		return "Z or zone offset such as -07"
+	case timeformat.ISO8601ColonTZ:
+		return "Z or zone offset such as -07:00"
+	case timeformat.ISO8601ColonSecondsTZ:
+		return "Z or zone offset such as -07:00:00"
+	case timeformat.NumTZ, timeformat.NumSecondsTZ, timeformat.NumShortTZ, timeformat.NumColonTZ, timeformat.NumColonSecondsTZ:
+		return "zone offset such as " + token.Text
+	case timeformat.FracSecond0:
+		return "fraction of a second with " + strconv.Itoa(token.Digits()) + " digits after " + strconv.Quote(string(token.Separator()))
+	case timeformat.FracSecond9:
+		return "fraction of a second"
+	case timeformat.Milliseconds:
+		return "three fraction digits"
+	case timeformat.Microseconds:
+		return "six fraction digits"
+	case timeformat.Nanoseconds:
+		return "nine fraction digits"
+	case timeformat.ISOWeekDay:
+		return "weekday number 1-7"
+	case timeformat.WeekDayNum:
+		return "weekday number 0-6"
+	case timeformat.Unix:
+		return "seconds since 1970"
+	}
+	return strconv.Quote(token.Text)
+}
+
+// foundAt returns the text at the start of s that a failed token was looking
+// at: a run of digits, a run of letters, or a single character.
+func foundAt(s string) string {
+	if s == "" {
+		return ""
+	}
+	class := func(c byte) int {
+		switch {
+		case '0' <= c && c <= '9':
+			return 1
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+			return 2
+		}
+		return 0
+	}
+	if c := class(s[0]); c != 0 {
+		n := 1
+		for n < len(s) && class(s[n]) == c {
+			n++
+		}
+		return s[:n]
+	}
+	_, n := utf8.DecodeRuneInString(s)
+	return s[:n]
+}
@@ -0,0 +1,135 @@
+package timeparse
+
+import (
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Time   string
+		Want   ParseError
+	}{
+		{
+			Layout: "2006 01 02",
+			Time:   "2021 13 24",
+			Want: ParseError{
+				Offset:   5,
+				Token:    "01",
+				Expected: "two-digit month 01-12",
+				Found:    "13",
+				Message:  "month out of range",
+			},
+		},
+		{
+			Layout: "2006-01-02",
+			Time:   "2021/12/24",
+			Want: ParseError{
+				Offset:   4,
+				Token:    "-",
+				Expected: `"-"`,
+				Found:    "/",
+			},
+		},
+		{
+			Layout: "2006 01 02",
+			Time:   "2021 1 24",
+			Want: ParseError{
+				Offset:   5,
+				Token:    "01",
+				Expected: "two-digit month 01-12",
+				Found:    "1",
+			},
+		},
+		{
+			Layout: "2006 January 2",
+			Time:   "2021 Febuary 28",
+			Want: ParseError{
+				Offset:   5,
+				Token:    "January",
+				Expected: "month name such as January",
+				Found:    "Febuary",
+			},
+		},
+		{
+			Layout: "2006 01 02",
+			Time:   "2021 02 30",
+			Want: ParseError{
+				Offset:   8,
+				Token:    "02",
+				Expected: "two-digit day of month 01-31",
+				Found:    "30",
+				Message:  "day out of range",
+			},
+		},
+		{
+			Layout: "2006 01 002",
+			Time:   "2021 02 031",
+			Want: ParseError{
+				Offset:   5,
+				Token:    "01",
+				Expected: "two-digit month 01-12",
+				Found:    "02",
+				Message:  "day-of-year does not match month",
+			},
+		},
+		{
+			Layout: "15:04",
+			Time:   "12:55:55",
+			Want: ParseError{
+				Offset:   5,
+				Expected: "end of input",
+				Found:    ":55",
+				Message:  "extra text",
+			},
+		},
+		{
+			Layout: "15:04:05",
+			Time:   "12:55",
+			Want: ParseError{
+				Offset:   5,
+				Token:    ":",
+				Expected: `":"`,
+			},
+		},
+	}
+	for _, test := range testData {
+		_, err := Parse(test.Layout, test.Time)
+		got, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("Parse time=%s, layout=%s, want *ParseError, got %v", test.Time, test.Layout, err)
+			continue
+		}
+		test.Want.Layout = test.Layout
+		test.Want.Value = test.Time
+		if *got != test.Want {
+			t.Errorf("Parse time=%s, layout=%s\nwant=%+v\ngot= %+v", test.Time, test.Layout, test.Want, *got)
+		}
+	}
+}
+
+func TestParseErrorSnippet(t *testing.T) {
+	_, err := Parse("2006 01 02", "2021 13 24")
+	want := "2021 13 24\n" +
+		"     ^^\n" +
+		`month out of range: expected two-digit month 01-12, found "13"`
+	if got := err.(*ParseError).Snippet(); got != want {
+		t.Errorf("Snippet\nwant=\n%s\ngot=\n%s", want, got)
+	}
+
+	_, err = Parse("15:04:05", "12:55")
+	want = "12:55\n" +
+		"     ^\n" +
+		`expected ":", found end of input`
+	if got := err.(*ParseError).Snippet(); got != want {
+		t.Errorf("Snippet\nwant=\n%s\ngot=\n%s", want, got)
+	}
+
+	_, err = Parse("2006-01-02T15:04:05Z07:00 MST", "2021-12-24T10:20:30+01:00 this value is way too long to show in full")
+	want = "2021-12-24T10:20:30+01:00 this value is way too long to show i...\n" +
+		"                          ^^^^\n" +
+		`expected zone abbreviation such as MST, found "this"`
+	if got := err.(*ParseError).Snippet(); got != want {
+		t.Errorf("Snippet\nwant=\n%s\ngot=\n%s", want, got)
+	}
+}
@@ -0,0 +1,105 @@
+package timeparse
+
+import (
+	"time"
+
+	"timeformattest/timeformat"
+)
+
+// Match is a timestamp found in free text.
+type Match struct {
+	Start, End int    // byte span of the timestamp in the text
+	Name       string // name of the layout that matched, for example "RFC3339"
+	Layout     string
+	Time       time.Time
+}
+
+// detector is a layout FindAll looks for.
+type detector struct {
+	name   string
+	layout *Layout
+	digit  bool // whether values start with a digit rather than a letter
+}
+
+// detectable lists the layouts FindAll recognizes, those of the registry
+// marked Detect. The longest match at a position wins; on equal length the
+// earlier layout does.
+var detectable = detectors(timeformat.Registry())
+
+func detectors(registry []timeformat.Named) []detector {
+	var d []detector
+	for _, n := range registry {
+		if !n.Detect {
+			continue
+		}
+		c := Compile(n.Layout)
+		first := c.tokens[0].Kind
+		d = append(d, detector{
+			name:   n.Name,
+			layout: c,
+			digit:  first != timeformat.LongWeekDay && first != timeformat.WeekDay && first != timeformat.Month,
+		})
+	}
+	return d
+}
+
+// maxMatch bounds the length of a timestamp FindAll looks for.
+const maxMatch = 64
+
+// FindAll returns the timestamps in text that are in one of the common
+// layouts of timeformat.Registry marked Detect: RFC 3339 and other ISO 8601
+// forms, the RFC 1123, 850 and 822 mail and HTTP dates, the time package's
+// Unix and ANSI C forms, syslog and Apache common log timestamps. Matches
+// are named as in the registry. A timestamp must not be directly preceded
+// or followed by a letter or digit. Values without a zone are in UTC.
+func FindAll(text string) []Match {
+	var matches []Match
+	for i := 0; i < len(text); {
+		if !isAlnum(text[i]) || i > 0 && isAlnum(text[i-1]) {
+			i++
+			continue
+		}
+		digit := isDigit(text, i)
+		tail := text[i:min(len(text), i+maxMatch)]
+		var best Match
+		for _, d := range detectable {
+			if d.digit != digit {
+				continue
+			}
+			t, n, ok := d.layout.parsePrefix(tail)
+			if !ok || n <= best.End-best.Start || i+n < len(text) && isAlnum(text[i+n]) {
+				continue
+			}
+			best = Match{Start: i, End: i + n, Name: d.name, Layout: d.layout.layout, Time: t}
+		}
+		if best.End == 0 {
+			i++
+			continue
+		}
+		matches = append(matches, best)
+		i = best.End
+	}
+	return matches
+}
+
+// parsePrefix parses the longest prefix of value the layout accepts and
+// returns its length.
+func (l *Layout) parsePrefix(value string) (time.Time, int, bool) {
+	if l.precheck(value) != nil {
+		return time.Time{}, 0, false
+	}
+	t, err := l.Parse(value)
+	if err == nil {
+		return t, len(value), true
+	}
+	if e := asParseError(err, l, value); e.Message == "extra text" {
+		if t, err = l.Parse(value[:e.Offset]); err == nil {
+			return t, e.Offset, true
+		}
+	}
+	return time.Time{}, 0, false
+}
+
+func isAlnum(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,73 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+
+	"timeformattest/timeformat"
+)
+
+func TestFindAll(t *testing.T) {
+	testData := []struct {
+		Text string
+		Want []Match
+	}{
+		{
+			Text: "2021-03-07T14:05:09.5Z worker started, next run at 2021-03-08 02:00:00 +0100",
+			Want: []Match{
+				{Start: 0, End: 22, Name: "RFC3339", Time: time.Date(2021, 3, 7, 14, 5, 9, 500000000, time.UTC)},
+				{Start: 51, End: 76, Name: "SQL timestamp with numeric offset", Time: time.Date(2021, 3, 8, 1, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			Text: `10.0.0.1 - - [07/Mar/2021:14:05:09 -0500] "GET / HTTP/1.1" 200`,
+			Want: []Match{
+				{Start: 14, End: 40, Name: "Apache CLF", Time: time.Date(2021, 3, 7, 19, 5, 9, 0, time.UTC)},
+			},
+		},
+		{
+			Text: "Mar  7 14:05:09 host sshd[42]: session closed",
+			Want: []Match{
+				{Start: 0, End: 15, Name: "syslog", Time: time.Date(0, 3, 7, 14, 5, 9, 0, time.UTC)},
+			},
+		},
+		{
+			Text: "Last-Modified: Sun, 07 Mar 2021 14:05:09 GMT; day 2021-066 of the year",
+			Want: []Match{
+				{Start: 15, End: 44, Name: "RFC1123", Time: time.Date(2021, 3, 7, 14, 5, 9, 0, time.UTC)},
+				{Start: 50, End: 58, Name: "ISO 8601 ordinal date", Time: time.Date(2021, 3, 7, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			Text: "build 20210307T140509Z, ref x2021-03-07, version 2021-03-07a, Mar 99 14:05:09",
+			Want: []Match{
+				{Start: 6, End: 22, Name: "ISO 8601 basic", Time: time.Date(2021, 3, 7, 14, 5, 9, 0, time.UTC)},
+			},
+		},
+		{Text: "no timestamps here, only 12:30 and 3/7"},
+	}
+	for _, test := range testData {
+		got := FindAll(test.Text)
+		if len(got) != len(test.Want) {
+			t.Errorf("FindAll %q\nwant=%v\ngot= %v", test.Text, test.Want, got)
+			continue
+		}
+		for i, m := range got {
+			w := test.Want[i]
+			if m.Start != w.Start || m.End != w.End || m.Name != w.Name || !m.Time.Equal(w.Time) {
+				t.Errorf("FindAll %q match %d\nwant=%+v\ngot= %+v", test.Text, i, w, m)
+			}
+		}
+	}
+}
+
+func TestDetectableRegistry(t *testing.T) {
+	for _, d := range detectable {
+		if n, ok := timeformat.LookupName(d.name); !ok || n.Layout != d.layout.layout || !n.Detect {
+			t.Errorf("detector %s %q is not in the registry: %+v", d.name, d.layout.layout, n)
+		}
+	}
+	if len(detectable) == 0 {
+		t.Error("no detectors")
+	}
+}
@@ -0,0 +1,78 @@
+//go:build glibc && cgo && linux
+
+package timeparse
+
+import (
+	"testing"
+	"time"
+
+	"timeformattest/internal/glibc"
+	"timeformattest/timeformat"
+)
+
+// strptimeDiffers explains the formats strptime reads differently from the
+// time package, which the compiled parser follows.
+var strptimeDiffers = map[string]string{
+	"%j": "strptime does not set the date from the day of the year without a year",
+	"%I": "strptime reads 12 without AM/PM as midnight, Go as noon",
+	"%l": "strptime reads 12 without AM/PM as midnight, Go as noon",
+	"%p": "strptime ignores AM/PM without a 12-hour clock",
+	"%P": "not a strptime directive",
+}
+
+// TestGlibcParse checks the compiled parser, given the strftime translation
+// of each directive, against strptime.
+func TestGlibcParse(t *testing.T) {
+	formats := []string{
+		"%Y-%m-%d %H:%M:%S",
+		"%F %T %z",
+		"%d/%b/%Y:%H:%M:%S %z",
+		"%a, %d %b %Y %T %z",
+		"%A %B %e %Y %I:%M:%S %p",
+		"%Y %j %R",
+		"%D %T",
+		"%s",
+	}
+	for _, c := range "YyCGgmBbhAauwVUWdejHkIlMSpPsZzcFDxTXrR%" {
+		formats = append(formats, "%"+string(c))
+	}
+	instants := []time.Time{
+		time.Date(2021, 1, 3, 0, 5, 9, 0, time.UTC),
+		time.Date(2020, 12, 31, 9, 0, 0, 0, time.FixedZone("", -9000)),
+		time.Date(1999, 6, 14, 12, 59, 59, 0, time.FixedZone("CEST", 7200)),
+		time.Date(2024, 2, 29, 23, 30, 1, 0, time.UTC),
+	}
+	for _, format := range formats {
+		layout, diags := timeformat.FromStrftime(format)
+		l := Compile(layout)
+		if strptimeDiffers[format] != "" {
+			continue
+		}
+		for _, ts := range instants {
+			if glibc.Differs(format, ts) != "" {
+				continue
+			}
+			text, _ := glibc.Strftime(ts, format)
+			want, err := glibc.Strptime(text, format)
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+			got, err := l.Parse(text)
+			if e, ok := err.(*ParseError); ok && e.Message == "token can only be formatted" {
+				break
+			}
+			if err != nil {
+				t.Errorf("strftime %q as %q, %q: %v %v", format, layout, text, err, diags)
+				continue
+			}
+			if got.Year() == 0 && want.Year() == 1900 {
+				// strptime counts a missing year from 1900
+				got = got.AddDate(1900, 0, 0)
+			}
+			if !got.Equal(want) {
+				t.Errorf("strftime %q as %q, %q\nglibc=%v\ngot=  %v %v", format, layout, text, want, got, diags)
+			}
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package timeparse
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"timeformattest/timeformat"
+)
+
+// Relaxation is a set of matching rules that lenient parsing may bend.
+type Relaxation uint
+
+const (
+	// Spacing lets whitespace in the layout match any run of spaces and
+	// tabs, including none, and allows whitespace around punctuation and at
+	// either end of the value.
+	Spacing Relaxation = 1 << iota
+	// Case matches AM/PM markers and literal letters in any case. Month and
+	// weekday names are matched ignoring case even when parsing strictly.
+	Case
+	// Abbreviations accepts full and abbreviated month and weekday names in
+	// place of each other, the variants "Sept", "Tues", "Weds", "Thur" and
+	// "Thurs", and a period after a name.
+	Abbreviations
+	// Meridiem accepts "a.m." and "p.m." for AM and PM.
+	Meridiem
+	// Ordinals accepts "st", "nd", "rd" or "th" after a day of the month.
+	Ordinals
+
+	// Lenient enables every relaxation.
+	Lenient = Spacing | Case | Abbreviations | Meridiem | Ordinals
+)
+
+var relaxationNames = []string{"spacing", "case", "abbreviations", "meridiem", "ordinals"}
+
+func (r Relaxation) String() string {
+	var names []string
+	for i, name := range relaxationNames {
+		if r&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "strict"
+	}
+	return strings.Join(names, "|")
+}
+
+// Applied records one place where a lenient parse bent a rule.
+type Applied struct {
+	Relaxation Relaxation
+	Offset     int    // byte offset in the value
+	Text       string // the text that needed the relaxation, empty for a missing space
+}
+
+// Result is the outcome of a lenient parse.
+type Result struct {
+	Time    time.Time
+	Applied []Applied // in the order they were needed
+}
+
+// Relaxations returns the relaxations the parse needed.
+func (r Result) Relaxations() Relaxation {
+	var used Relaxation
+	for _, a := range r.Applied {
+		used |= a.Relaxation
+	}
+	return used
+}
+
+// ParseLenient is like Parse but bends the rules in allow where the value
+// needs it. The result records every place where that happened.
+func ParseLenient(layout, value string, allow Relaxation) (Result, error) {
+	return Compile(layout).ParseLenient(value, allow)
+}
+
+// ParseLenient is like Parse but bends the rules in allow where the value
+// needs it. The result records every place where that happened.
+func (l *Layout) ParseLenient(value string, allow Relaxation) (Result, error) {
+	r := &relaxer{allow: allow}
+	t, err := l.parse(value, time.UTC, time.Local, r)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Time: t, Applied: r.applied}, nil
+}
+
+// ParseLenientInLocation is like ParseLenient but uses time.ParseInLocation
+// semantics.
+func (l *Layout) ParseLenientInLocation(value string, loc *time.Location, allow Relaxation) (Result, error) {
+	r := &relaxer{allow: allow}
+	t, err := l.parse(value, loc, loc, r)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Time: t, Applied: r.applied}, nil
+}
+
+// relaxer holds the state of one lenient parse.
+type relaxer struct {
+	allow   Relaxation
+	applied []Applied
+}
+
+// note records that relaxation was needed for s[:n], where s is the
+// unparsed tail of value.
+func (r *relaxer) note(relaxation Relaxation, value, s string, n int) {
+	r.applied = append(r.applied, Applied{
+		Relaxation: relaxation,
+		Offset:     len(value) - len(s),
+		Text:       s[:n],
+	})
+}
+
+// trimSpace drops whitespace that strict parsing would reject.
+func (r *relaxer) trimSpace(s, value string) string {
+	if r.allow&Spacing == 0 {
+		return s
+	}
+	if n := spaces(s); n > 0 {
+		r.note(Spacing, value, s, n)
+		s = s[n:]
+	}
+	return s
+}
+
+// skip is the lenient form of the package-level skip.
+func (r *relaxer) skip(s, prefix, value string) (string, bool) {
+	for prefix != "" {
+		if prefix[0] == ' ' {
+			prefix = cutspace(prefix)
+			if r.allow&Spacing == 0 {
+				if s != "" && s[0] != ' ' {
+					return s, true
+				}
+				s = cutspace(s)
+				continue
+			}
+			n := spaces(s)
+			if n == 0 && s != "" || strings.Contains(s[:n], "\t") {
+				r.note(Spacing, value, s, n)
+			}
+			s = s[n:]
+			continue
+		}
+		s = r.trimSpace(s, value)
+		if s == "" {
+			return s, true
+		}
+		if s[0] != prefix[0] {
+			if r.allow&Case == 0 || !match(s[:1], prefix[:1]) {
+				return s, true
+			}
+			r.note(Case, value, s, 1)
+		}
+		s = s[1:]
+		prefix = prefix[1:]
+	}
+	return r.trimSpace(s, value), false
+}
+
+// name is one accepted spelling of a month or weekday.
+type name struct {
+	index int // zero-based month, or weekday counted from Sunday
+	text  string
+	kind  timeformat.Kind // the layout token the spelling belongs to, Literal for variants
+}
+
+// spellings lists every spelling longest first, so that "Sept" is tried
+// before "Sep".
+func spellings(long, short []string, longKind, shortKind timeformat.Kind, variants ...name) []name {
+	var names []name
+	for i := range long {
+		names = append(names, name{i, long[i], longKind}, name{i, short[i], shortKind})
+	}
+	names = append(names, variants...)
+	sort.SliceStable(names, func(i, j int) bool { return len(names[i].text) > len(names[j].text) })
+	return names
+}
+
+// lookup is the lenient form of the package-level lookup for a name token
+// of the given kind. A period after the name is only taken when the layout
+// does not continue with one.
+func (r *relaxer) lookup(s, value string, names []name, kind timeformat.Kind, follow string) (int, string, bool) {
+	for _, name := range names {
+		if name.kind != kind && r.allow&Abbreviations == 0 {
+			continue
+		}
+		if len(s) < len(name.text) || !match(s[:len(name.text)], name.text) {
+			continue
+		}
+		n := len(name.text)
+		dot := r.allow&Abbreviations != 0 && len(s) > n && s[n] == '.' && !strings.HasPrefix(follow, ".")
+		if name.kind != kind || dot {
+			if dot {
+				n++
+			}
+			r.note(Abbreviations, value, s, n)
+		}
+		return name.index, s[n:], false
+	}
+	return -1, s, true
+}
+
+// ordinal drops an ordinal suffix after a day of the month.
+func (r *relaxer) ordinal(s, value string) string {
+	if r.allow&Ordinals == 0 || len(s) < 2 {
+		return s
+	}
+	switch strings.ToLower(s[:2]) {
+	case "st", "nd", "rd", "th":
+		if len(s) > 2 && isLetter(s[2]) {
+			return s
+		}
+		r.note(Ordinals, value, s, 2)
+		return s[2:]
+	}
+	return s
+}
+
+// meridiem is the lenient form of matching a PM or pm token whose markers
+// are am and pm.
+func (r *relaxer) meridiem(s, value, am, pm string) (isPM bool, rest string, err bool) {
+	// "a.m." and "p.m." with or without the last period
+	if r.allow&Meridiem != 0 && len(s) >= 3 && s[1] == '.' && (s[2] == 'm' || s[2] == 'M') {
+		n := 3
+		if len(s) > 3 && s[3] == '.' {
+			n++
+		}
+		if isPM, exact, ok := r.marker(s[:1]+s[2:3], am, pm); ok {
+			r.note(Meridiem, value, s, n)
+			if !exact {
+				r.note(Case, value, s, n)
+			}
+			return isPM, s[n:], false
+		}
+		return false, s, true
+	}
+	for _, marker := range []string{pm, am} {
+		if len(s) < len(marker) {
+			continue
+		}
+		if isPM, exact, ok := r.marker(s[:len(marker)], am, pm); ok {
+			if !exact {
+				r.note(Case, value, s, len(marker))
+			}
+			return isPM, s[len(marker):], false
+		}
+	}
+	return false, s, true
+}
+
+// marker matches text against the am and pm markers, ignoring case when
+// the Case relaxation allows it.
+func (r *relaxer) marker(text, am, pm string) (isPM, exact, ok bool) {
+	switch {
+	case text == pm:
+		return true, true, true
+	case text == am:
+		return false, true, true
+	case r.allow&Case == 0:
+		return false, false, false
+	case len(text) == len(pm) && match(text, pm):
+		return true, false, true
+	case len(text) == len(am) && match(text, am):
+		return false, false, true
+	}
+	return false, false, false
+}
+
+func spaces(s string) int {
+	n := 0
+	for n < len(s) && (s[n] == ' ' || s[n] == '\t') {
+		n++
+	}
+	return n
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
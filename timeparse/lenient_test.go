@@ -0,0 +1,163 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLenient(t *testing.T) {
+	testData := []struct {
+		Layout  string
+		Time    string
+		Allow   Relaxation
+		Want    time.Time
+		Applied Relaxation
+	}{
+		{
+			Layout:  "2006 01 02",
+			Time:    "2021 12 24",
+			Allow:   Lenient,
+			Want:    time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+			Applied: 0,
+		},
+		{
+			Layout:  "Jan 2, 2006",
+			Time:    "  DEC 24 ,\t2021 ",
+			Allow:   Spacing,
+			Want:    time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+			Applied: Spacing,
+		},
+		{
+			Layout:  "Jan 2 2006",
+			Time:    "Dec24 2021",
+			Allow:   Spacing,
+			Want:    time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+			Applied: Spacing,
+		},
+		{
+			Layout:  "Jan 2 2006",
+			Time:    "Sept. 24 2021",
+			Allow:   Abbreviations,
+			Want:    time.Date(2021, 9, 24, 0, 0, 0, 0, time.UTC),
+			Applied: Abbreviations,
+		},
+		{
+			Layout:  "Jan 2 2006",
+			Time:    "December 24 2021",
+			Allow:   Abbreviations,
+			Want:    time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+			Applied: Abbreviations,
+		},
+		{
+			Layout:  "Mon, January 2 2006",
+			Time:    "Tues, Dec. 21 2021",
+			Allow:   Abbreviations,
+			Want:    time.Date(2021, 12, 21, 0, 0, 0, 0, time.UTC),
+			Applied: Abbreviations,
+		},
+		{
+			Layout:  "Jan. 2 2006",
+			Time:    "Dec. 24 2021",
+			Allow:   Abbreviations,
+			Want:    time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+			Applied: 0,
+		},
+		{
+			Layout:  "January 2 2006",
+			Time:    "December 24th 2021",
+			Allow:   Ordinals,
+			Want:    time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+			Applied: Ordinals,
+		},
+		{
+			Layout:  "3:04 PM",
+			Time:    "3:04 p.m.",
+			Allow:   Meridiem | Case,
+			Want:    time.Date(0, 1, 1, 15, 4, 0, 0, time.UTC),
+			Applied: Meridiem | Case,
+		},
+		{
+			Layout:  "3:04 pm",
+			Time:    "12:04 a.m.",
+			Allow:   Meridiem,
+			Want:    time.Date(0, 1, 1, 0, 4, 0, 0, time.UTC),
+			Applied: Meridiem,
+		},
+		{
+			Layout:  "3:04 PM",
+			Time:    "3:04 Pm",
+			Allow:   Case,
+			Want:    time.Date(0, 1, 1, 15, 4, 0, 0, time.UTC),
+			Applied: Case,
+		},
+		{
+			Layout:  "2006-01-02T15:04:05",
+			Time:    "2021-12-24t10:20:30",
+			Allow:   Case,
+			Want:    time.Date(2021, 12, 24, 10, 20, 30, 0, time.UTC),
+			Applied: Case,
+		},
+		{
+			Layout:  "January 2, 2006 3:04 pm",
+			Time:    " sept 3rd ,2021  11:05 P.M. ",
+			Allow:   Lenient,
+			Want:    time.Date(2021, 9, 3, 23, 5, 0, 0, time.UTC),
+			Applied: Lenient,
+		},
+	}
+	for _, test := range testData {
+		got, err := ParseLenient(test.Layout, test.Time, test.Allow)
+		if err != nil {
+			t.Errorf("%v\n%s", err, err.(*ParseError).Snippet())
+			continue
+		}
+		if test.Want != got.Time {
+			t.Errorf("ParseLenient time=%s, layout=%s, want=%v, got=%v", test.Time, test.Layout, test.Want, got.Time)
+		}
+		if test.Applied != got.Relaxations() {
+			t.Errorf("ParseLenient time=%s, layout=%s, want applied=%v, got=%v %+v", test.Time, test.Layout, test.Applied, got.Relaxations(), got.Applied)
+		}
+	}
+}
+
+func TestParseLenientOnlyAllowed(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Time   string
+		Allow  Relaxation
+	}{
+		{Layout: "Jan 2 2006", Time: "Dec24 2021", Allow: Lenient &^ Spacing},
+		{Layout: "Jan 2 2006", Time: "Sept 24 2021", Allow: Lenient &^ Abbreviations},
+		{Layout: "Jan 2 2006", Time: "Dec 24th 2021", Allow: Lenient &^ Ordinals},
+		{Layout: "3:04 PM", Time: "3:04 p.m.", Allow: Lenient &^ Meridiem},
+		{Layout: "3:04 PM", Time: "3:04 p.m.", Allow: Meridiem},
+		{Layout: "3:04 PM", Time: "3:04 pm", Allow: Lenient &^ Case},
+	}
+	for _, test := range testData {
+		if got, err := ParseLenient(test.Layout, test.Time, test.Allow); err == nil {
+			t.Errorf("ParseLenient time=%s, layout=%s, allow=%v, want error, got=%v", test.Time, test.Layout, test.Allow, got.Time)
+		}
+		if got, err := Parse(test.Layout, test.Time); err == nil {
+			t.Errorf("Parse time=%s, layout=%s, want error, got=%v", test.Time, test.Layout, got)
+		}
+	}
+}
+
+func TestApplied(t *testing.T) {
+	got, err := ParseLenient("Jan 2 2006", "Sept 3rd  2021", Lenient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Applied{
+		{Relaxation: Abbreviations, Offset: 0, Text: "Sept"},
+		{Relaxation: Ordinals, Offset: 6, Text: "rd"},
+	}
+	if len(got.Applied) != len(want) {
+		t.Fatalf("ParseLenient want applied=%+v, got=%+v", want, got.Applied)
+	}
+	for i := range want {
+		if got.Applied[i] != want[i] {
+			t.Errorf("ParseLenient want applied=%+v, got=%+v", want[i], got.Applied[i])
+		}
+	}
+}
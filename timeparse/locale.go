@@ -0,0 +1,55 @@
+package timeparse
+
+import "timeformattest/timeformat"
+
+// words are the spellings of names and markers a Layout matches.
+type words struct {
+	locale                               *timeformat.Locale
+	months, shortMonths, days, shortDays []string
+	am, pm                               string
+
+	// every accepted spelling, for lenient parsing
+	monthSpellings, daySpellings []name
+}
+
+var englishWords = newWords(timeformat.English)
+
+func newWords(locale *timeformat.Locale) *words {
+	w := &words{
+		locale:      locale,
+		months:      locale.Months[:],
+		shortMonths: locale.ShortMonths[:],
+		days:        locale.Days[:],
+		shortDays:   locale.ShortDays[:],
+		am:          locale.AM,
+		pm:          locale.PM,
+	}
+	var monthVariants, dayVariants []name
+	if locale == timeformat.English {
+		monthVariants = []name{{8, "Sept", timeformat.Literal}}
+		dayVariants = []name{
+			{2, "Tues", timeformat.Literal}, {3, "Weds", timeformat.Literal},
+			{4, "Thur", timeformat.Literal}, {4, "Thurs", timeformat.Literal},
+		}
+	}
+	w.monthSpellings = spellings(w.months, w.shortMonths, timeformat.LongMonth, timeformat.Month, monthVariants...)
+	w.daySpellings = spellings(w.days, w.shortDays, timeformat.LongWeekDay, timeformat.WeekDay, dayVariants...)
+	return w
+}
+
+// WithLocale returns a copy of l that matches names, AM/PM markers and
+// ordinals as written in locale.
+func (l *Layout) WithLocale(locale *timeformat.Locale) *Layout {
+	c := *l
+	if locale == timeformat.English {
+		c.words = englishWords
+	} else {
+		c.words = newWords(locale)
+	}
+	if c.python != nil {
+		c.python = compilePython(c.tokens, c.words)
+	} else {
+		c.fixed = fixedBytes(c.tokens, c.words)
+	}
+	return &c
+}
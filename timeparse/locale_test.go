@@ -0,0 +1,101 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+
+	"timeformattest/timeformat"
+)
+
+func TestParseOrdinal(t *testing.T) {
+	testData := []struct {
+		Locale *timeformat.Locale
+		Layout string
+		Time   string
+		Want   time.Time
+	}{
+		{
+			Locale: timeformat.English,
+			Layout: "January {2nd}, 2006",
+			Time:   "December 24th, 2021",
+			Want:   time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Locale: timeformat.English,
+			Layout: "Jan {2nd} 2006",
+			Time:   "Feb 1st 2021",
+			Want:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Locale: timeformat.English,
+			Layout: "Jan {2nd} 2006",
+			Time:   "Feb 22nd 2021",
+			Want:   time.Date(2021, 2, 22, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Locale: timeformat.German,
+			Layout: "Monday, {2nd} January 2006",
+			Time:   "Freitag, 24. Dezember 2021",
+			Want:   time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Locale: timeformat.German,
+			Layout: "Mon {2nd} Jan 2006",
+			Time:   "Mo 1. Mär 2021",
+			Want:   time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Locale: timeformat.French,
+			Layout: "{2nd} January 2006",
+			Time:   "1er février 2021",
+			Want:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Locale: timeformat.French,
+			Layout: "{2nd} January 2006",
+			Time:   "2 février 2021",
+			Want:   time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, test := range testData {
+		l := Compile(test.Layout).WithLocale(test.Locale)
+		got, err := l.Parse(test.Time)
+		if err != nil {
+			t.Errorf("%v\n%s", err, err.(*ParseError).Snippet())
+			continue
+		}
+		if test.Want != got {
+			t.Errorf("Parse locale=%s, time=%s, layout=%s, want=%v, got=%v", test.Locale.Tag, test.Time, test.Layout, test.Want, got)
+		}
+		formatted := timeformat.Compile(test.Layout).WithLocale(test.Locale).Format(got)
+		if formatted != test.Time {
+			t.Errorf("Format locale=%s, layout=%s, want=%s, got=%s", test.Locale.Tag, test.Layout, test.Time, formatted)
+		}
+	}
+}
+
+func TestParseOrdinalWrongSuffix(t *testing.T) {
+	testData := []struct {
+		Locale *timeformat.Locale
+		Layout string
+		Time   string
+	}{
+		{Locale: timeformat.English, Layout: "Jan {2nd}", Time: "Feb 22th"},
+		{Locale: timeformat.English, Layout: "Jan {2nd}", Time: "Feb 22"},
+		{Locale: timeformat.German, Layout: "{2nd} January", Time: "24 Dezember"},
+		{Locale: timeformat.French, Layout: "{2nd} January", Time: "1 février"},
+	}
+	for _, test := range testData {
+		if got, err := Compile(test.Layout).WithLocale(test.Locale).Parse(test.Time); err == nil {
+			t.Errorf("Parse locale=%s, time=%s, layout=%s, want error, got=%v", test.Locale.Tag, test.Time, test.Layout, got)
+		}
+	}
+
+	got, err := Compile("Jan {2nd}").ParseLenient("Feb 22th", Ordinals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Relaxations() != Ordinals {
+		t.Errorf("ParseLenient want applied=%v, got=%v", Ordinals, got.Relaxations())
+	}
+}
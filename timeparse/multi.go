@@ -0,0 +1,112 @@
+package timeparse
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MultiLayout parses values that may come in any of several layouts.
+type MultiLayout struct {
+	layouts []*Layout
+}
+
+// Multi compiles layouts to be tried in the given order.
+func Multi(layouts ...string) *MultiLayout {
+	m := &MultiLayout{layouts: make([]*Layout, len(layouts))}
+	for i, layout := range layouts {
+		m.layouts[i] = Compile(layout)
+	}
+	return m
+}
+
+// Layouts returns the source layouts in the order they are tried.
+func (m *MultiLayout) Layouts() []string {
+	layouts := make([]string, len(m.layouts))
+	for i, l := range m.layouts {
+		layouts[i] = l.layout
+	}
+	return layouts
+}
+
+// Parse returns the time parsed by the first layout that accepts value,
+// together with that layout. Layouts whose fixed literals do not match
+// value are skipped without a full parse. When no layout matches the
+// error is a *MultiError.
+func (m *MultiLayout) Parse(value string) (time.Time, string, error) {
+	return m.parse(value, func(l *Layout) (time.Time, error) { return l.Parse(value) })
+}
+
+// ParseInLocation is like Parse but uses time.ParseInLocation semantics.
+func (m *MultiLayout) ParseInLocation(value string, loc *time.Location) (time.Time, string, error) {
+	return m.parse(value, func(l *Layout) (time.Time, error) { return l.ParseInLocation(value, loc) })
+}
+
+func (m *MultiLayout) parse(value string, parse func(*Layout) (time.Time, error)) (time.Time, string, error) {
+	errs := make([]*ParseError, len(m.layouts))
+	var pruned []int
+	for i, l := range m.layouts {
+		if errs[i] = l.precheck(value); errs[i] != nil {
+			pruned = append(pruned, i)
+			continue
+		}
+		t, err := parse(l)
+		if err == nil {
+			return t, l.layout, nil
+		}
+		errs[i] = asParseError(err, l, value)
+	}
+	// A pruned layout cannot accept value, whose fixed literals differ, but
+	// its error only tells which literal. Parse it so that the reported
+	// offset is how far it really got.
+	for _, i := range pruned {
+		if _, err := parse(m.layouts[i]); err != nil {
+			errs[i] = asParseError(err, m.layouts[i], value)
+		}
+	}
+	return time.Time{}, "", &MultiError{Value: value, Errors: errs}
+}
+
+// asParseError returns err as a *ParseError, wrapping errors of other
+// types.
+func asParseError(err error, l *Layout, value string) *ParseError {
+	var e *ParseError
+	if errors.As(err, &e) {
+		return e
+	}
+	return &ParseError{Layout: l.layout, Value: value, Message: err.Error()}
+}
+
+// MultiError reports why each layout of a MultiLayout rejected a value.
+type MultiError struct {
+	Value  string
+	Errors []*ParseError // one per layout, in layout order
+}
+
+// Furthest returns the failure that got furthest into the value, preferring
+// the earlier layout on ties.
+func (e *MultiError) Furthest() *ParseError {
+	var furthest *ParseError
+	for _, err := range e.Errors {
+		if furthest == nil || err.Offset > furthest.Offset {
+			furthest = err
+		}
+	}
+	return furthest
+}
+
+func (e *MultiError) Error() string {
+	if len(e.Errors) == 0 {
+		return "parsing time " + strconv.Quote(e.Value) + ": no layouts"
+	}
+	var b strings.Builder
+	b.WriteString("parsing time ")
+	b.WriteString(strconv.Quote(e.Value))
+	b.WriteString(": no layout matched")
+	for _, err := range e.Errors {
+		b.WriteString("\n\t")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
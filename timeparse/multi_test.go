@@ -0,0 +1,112 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMulti(t *testing.T) {
+	parser := Multi(time.RFC3339, "2006-01-02", "02/01/2006", "Jan _2 15:04:05")
+	testData := []struct {
+		Time   string
+		Layout string
+		Want   time.Time
+	}{
+		{
+			Time:   "2021-12-24T10:20:30Z",
+			Layout: time.RFC3339,
+			Want:   time.Date(2021, 12, 24, 10, 20, 30, 0, time.UTC),
+		},
+		{
+			Time:   "2021-12-24",
+			Layout: "2006-01-02",
+			Want:   time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Time:   "24/12/2021",
+			Layout: "02/01/2006",
+			Want:   time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Time:   "Dec  5 10:20:30",
+			Layout: "Jan _2 15:04:05",
+			Want:   time.Date(0, 12, 5, 10, 20, 30, 0, time.UTC),
+		},
+	}
+	for _, test := range testData {
+		got, layout, err := parser.Parse(test.Time)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if layout != test.Layout || !test.Want.Equal(got) {
+			t.Errorf("Multi time=%s, want=%v (%s), got=%v (%s)", test.Time, test.Want, test.Layout, got, layout)
+		}
+	}
+}
+
+func TestMultiError(t *testing.T) {
+	parser := Multi(time.RFC3339, "2006-01-02", "02/01/2006")
+	testData := []struct {
+		Time     string
+		Offsets  []int
+		Furthest string
+	}{
+		{
+			Time:     "2021-12-24T10:20",
+			Offsets:  []int{16, 10, 2},
+			Furthest: time.RFC3339,
+		},
+		{
+			Time:     "2021-12-2x",
+			Offsets:  []int{8, 8, 2},
+			Furthest: time.RFC3339,
+		},
+		{
+			Time:     "24/13/2021",
+			Offsets:  []int{0, 0, 3},
+			Furthest: "02/01/2006",
+		},
+	}
+	for _, test := range testData {
+		_, _, err := parser.Parse(test.Time)
+		multiErr, ok := err.(*MultiError)
+		if !ok {
+			t.Errorf("Multi time=%s, want *MultiError, got %v", test.Time, err)
+			continue
+		}
+		for i, err := range multiErr.Errors {
+			if err.Offset != test.Offsets[i] {
+				t.Errorf("Multi time=%s, layout=%s, want offset=%d, got=%d (%v)", test.Time, err.Layout, test.Offsets[i], err.Offset, err)
+			}
+		}
+		if furthest := multiErr.Furthest(); furthest.Layout != test.Furthest {
+			t.Errorf("Multi time=%s, want furthest=%s, got=%s", test.Time, test.Furthest, furthest.Layout)
+		}
+	}
+}
+
+func TestMultiPrunesOnLiterals(t *testing.T) {
+	l := Compile("2006-01-02T15:04:05Z07:00")
+	if err := l.precheck("2021/12/24"); err == nil || err.Offset != 4 {
+		t.Errorf("precheck want offset=4, got %v", err)
+	}
+	if err := l.precheck("2021-12-24T10:20:30Z"); err != nil {
+		t.Errorf("precheck want no error, got %v", err)
+	}
+}
+
+func TestMultiOrder(t *testing.T) {
+	// both layouts accept the value
+	m := Multi("2006-1-2", "2006-01-02")
+	got, layout, err := m.Parse("2021-12-24")
+	if want := time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC); err != nil || !got.Equal(want) || layout != "2006-1-2" {
+		t.Errorf("Multi want=%v %q, got=%v %q %v", want, "2006-1-2", got, layout, err)
+	}
+	// the first layout is pruned on its fixed literals
+	m = Multi("02/01/2006", "2006-01-02")
+	got, layout, err = m.ParseInLocation("2021-12-24", time.UTC)
+	if want := time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC); err != nil || !got.Equal(want) || layout != "2006-01-02" {
+		t.Errorf("Multi want=%v %q, got=%v %q %v", want, "2006-01-02", got, layout, err)
+	}
+}
@@ -0,0 +1,923 @@
+package timeparse
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"timeformattest/timeformat"
+)
+
+// Layout is a Go layout compiled for repeated parsing. Parsing follows the
+// rules of time.Parse; the layout is tokenized once instead of on every call.
+type Layout struct {
+	layout string
+	tokens []timeformat.Token
+	fixed  []fixedByte
+	words  *words
+	python *pythonRegexp // match as Python's strptime, see CompilePython
+}
+
+// fixedByte is a literal byte whose offset in any matching value is known
+// from the layout alone. They allow rejecting a value without parsing it.
+type fixedByte struct {
+	offset int
+	b      byte
+	token  int
+}
+
+// Compile tokenizes layout for use with Parse. Names and ordinals are
+// matched in English until WithLocale says otherwise.
+func Compile(layout string) *Layout {
+	l := &Layout{layout: layout, tokens: timeformat.Tokenize(layout), words: englishWords}
+	l.fixed = fixedBytes(l.tokens, l.words)
+	return l
+}
+
+// Parse is like time.Parse but compiles the layout first.
+func Parse(layout, value string) (time.Time, error) {
+	return Compile(layout).Parse(value)
+}
+
+// String returns the source layout.
+func (l *Layout) String() string {
+	return l.layout
+}
+
+// Parse parses value like time.Parse does with the compiled layout.
+func (l *Layout) Parse(value string) (time.Time, error) {
+	return l.parse(value, time.UTC, time.Local, nil)
+}
+
+// ParseInLocation parses value like time.ParseInLocation does with the
+// compiled layout.
+func (l *Layout) ParseInLocation(value string, loc *time.Location) (time.Time, error) {
+	return l.parse(value, loc, loc, nil)
+}
+
+// fixedBytes collects the literal bytes that come before the first token of
+// variable width. Spaces end the run because any number of them may match.
+func fixedBytes(tokens []timeformat.Token, w *words) []fixedByte {
+	var fixed []fixedByte
+	offset := 0
+	for i, token := range tokens {
+		if token.Kind == timeformat.Literal {
+			for j := 0; j < len(token.Text); j++ {
+				if token.Text[j] == ' ' {
+					return fixed
+				}
+				fixed = append(fixed, fixedByte{offset: offset, b: token.Text[j], token: i})
+				offset++
+			}
+			continue
+		}
+		n := fixedWidth(token, w)
+		if n == 0 {
+			return fixed
+		}
+		if token.Kind == timeformat.ZeroSecond {
+			// an unannounced fraction may follow the seconds
+			if i+1 >= len(tokens) || tokens[i+1].Kind != timeformat.FracSecond0 {
+				return fixed
+			}
+		}
+		offset += n
+	}
+	return fixed
+}
+
+// fixedWidth returns the number of bytes a token always consumes, or zero
+// when that depends on the value.
+func fixedWidth(token timeformat.Token, w *words) int {
+	switch token.Kind {
+	case timeformat.LongYear:
+		return 4
+	case timeformat.Year, timeformat.ZeroMonth, timeformat.ZeroDay, timeformat.ZeroHour12,
+		timeformat.ZeroMinute, timeformat.ZeroSecond:
+		return 2
+	case timeformat.ZeroYearDay, timeformat.NumShortTZ:
+		return 3
+	case timeformat.Month:
+		return sameLength(w.shortMonths...)
+	case timeformat.WeekDay:
+		return sameLength(w.shortDays...)
+	case timeformat.PM, timeformat.LowerPM:
+		return sameLength(w.am, w.pm)
+	case timeformat.NumTZ:
+		return 5
+	case timeformat.NumColonTZ:
+		return 6
+	case timeformat.NumSecondsTZ:
+		return 7
+	case timeformat.NumColonSecondsTZ:
+		return 9
+	case timeformat.FracSecond0:
+		return len(token.Text)
+	case timeformat.ISOWeekDay, timeformat.WeekDayNum:
+		return 1
+	case timeformat.Milliseconds:
+		return 3
+	case timeformat.Microseconds:
+		return 6
+	case timeformat.Nanoseconds:
+		return 9
+	}
+	return 0
+}
+
+// sameLength returns the length of the strings if they all have the same
+// length, or zero.
+func sameLength(s ...string) int {
+	for _, e := range s[1:] {
+		if len(e) != len(s[0]) {
+			return 0
+		}
+	}
+	return len(s[0])
+}
+
+// precheck compares the fixed literal bytes of the layout with value.
+func (l *Layout) precheck(value string) *ParseError {
+	for _, f := range l.fixed {
+		if f.offset >= len(value) || value[f.offset] != f.b {
+			token := l.tokens[f.token]
+			offset := min(f.offset, len(value))
+			return &ParseError{
+				Layout:   l.layout,
+				Value:    value,
+				Offset:   offset,
+				Token:    token.Text,
+				Expected: expectation(token),
+				Found:    foundAt(value[offset:]),
+			}
+		}
+	}
+	return nil
+}
+
+// parse implements time.Parse over the compiled tokens. A non-nil r relaxes
+// the matching rules and records where it did so.
+func (l *Layout) parse(value string, defaultLocation, local *time.Location, r *relaxer) (time.Time, error) {
+	var (
+		rangeErr string // set if a value is out of range
+		amSet    bool   // do we need to subtract 12 from the hour for midnight?
+		pmSet    bool   // do we need to add 12 to the hour?
+
+		year       int
+		month      = -1
+		day        = -1
+		yday       = -1
+		hour       int
+		min        int
+		sec        int
+		nsec       int
+		utc        bool
+		zoneOffset = -1
+		zoneName   string
+		unix       int64
+		unixSet    bool
+		hour12     bool // was the hour read on a 12-hour clock?
+	)
+
+	// where the fields checked after the loop were read, for error reporting
+	var monthAt, dayAt, ydayAt field
+
+	s := value
+	fail := func(f field, message string) (time.Time, error) {
+		found := foundAt(f.at)
+		if f.token.Kind == timeformat.Literal && f.token.Text == "" {
+			found = f.at
+		}
+		return time.Time{}, &ParseError{
+			Layout:   l.layout,
+			Value:    value,
+			Offset:   len(value) - len(f.at),
+			Token:    f.token.Text,
+			Expected: expectation(f.token),
+			Found:    found,
+			Message:  message,
+		}
+	}
+
+	// the text of each token when matching as strptime
+	var bounds []int
+	if l.python != nil {
+		// strptime's default year
+		year = 1900
+		var i, offset int
+		if bounds, i, offset = l.python.split(value); bounds == nil {
+			if i == len(l.tokens) {
+				return fail(field{value[offset:], timeformat.Token{}}, "extra text")
+			}
+			return fail(field{value[offset:], l.tokens[i]}, "")
+		}
+	}
+	if r != nil {
+		s = r.trimSpace(s, value)
+	}
+	for i, token := range l.tokens {
+		var err bool
+		hold := s
+		if bounds != nil {
+			hold, s = value[bounds[2*i+2]:], value[bounds[2*i+2]:bounds[2*i+3]]
+		}
+		switch token.Kind {
+		case timeformat.Literal:
+			if bounds != nil {
+				// matched ignoring case and the kind of whitespace
+				s = ""
+				break
+			}
+			if r != nil {
+				s, err = r.skip(s, token.Text, value)
+				break
+			}
+			s, err = skip(s, token.Text)
+		case timeformat.Year:
+			if len(s) < 2 {
+				err = true
+				break
+			}
+			year, err = atoi(s[:2])
+			s = s[2:]
+			if year >= 69 { // Unix time starts Dec 31 1969 in some time zones
+				year += 1900
+			} else {
+				year += 2000
+			}
+		case timeformat.LongYear:
+			if len(s) < 4 || !isDigit(s, 0) {
+				err = true
+				break
+			}
+			year, err = atoi(s[:4])
+			s = s[4:]
+		case timeformat.Month:
+			switch {
+			case bounds != nil:
+				month, s, err = lookupName(l.words.shortMonths, s)
+			case r != nil:
+				month, s, err = r.lookup(s, value, l.words.monthSpellings, token.Kind, l.follow(i))
+			default:
+				month, s, err = lookup(l.words.shortMonths, s)
+			}
+			month++
+		case timeformat.LongMonth:
+			switch {
+			case bounds != nil:
+				month, s, err = lookupName(l.words.months, s)
+			case r != nil:
+				month, s, err = r.lookup(s, value, l.words.monthSpellings, token.Kind, l.follow(i))
+			default:
+				month, s, err = lookup(l.words.months, s)
+			}
+			month++
+		case timeformat.NumMonth, timeformat.ZeroMonth:
+			month, s, err = getnum(s, token.Kind == timeformat.ZeroMonth && l.python == nil)
+			if !err && (month <= 0 || 12 < month) {
+				rangeErr = "month"
+			}
+		case timeformat.WeekDay:
+			// the weekday is only checked for syntax
+			switch {
+			case bounds != nil:
+				_, s, err = lookupName(l.words.shortDays, s)
+			case r != nil:
+				_, s, err = r.lookup(s, value, l.words.daySpellings, token.Kind, l.follow(i))
+			default:
+				_, s, err = lookup(l.words.shortDays, s)
+			}
+		case timeformat.LongWeekDay:
+			if bounds != nil {
+				_, s, err = lookupName(l.words.days, s)
+				break
+			}
+			if r != nil {
+				_, s, err = r.lookup(s, value, l.words.daySpellings, token.Kind, l.follow(i))
+				break
+			}
+			_, s, err = lookup(l.words.days, s)
+		case timeformat.Day, timeformat.UnderDay, timeformat.ZeroDay:
+			if (token.Kind == timeformat.UnderDay || l.python != nil) && len(s) > 0 && s[0] == ' ' {
+				s = s[1:]
+			}
+			// any one- or two-digit day, validated with month and year at the end
+			day, s, err = getnum(s, token.Kind == timeformat.ZeroDay && l.python == nil)
+			if r != nil && !err {
+				s = r.ordinal(s, value)
+			}
+		case timeformat.OrdinalDay:
+			day, s, err = getnum(s, false)
+			if err {
+				break
+			}
+			suffix := strings.TrimPrefix(l.words.locale.Ordinal(day), strconv.Itoa(day))
+			switch {
+			case strings.HasPrefix(s, suffix):
+				s = s[len(suffix):]
+			case r != nil && r.allow&Ordinals != 0:
+				s = r.ordinal(s, value)
+			default:
+				err = true
+			}
+		case timeformat.UnderYearDay, timeformat.ZeroYearDay:
+			for j := 0; j < 2; j++ {
+				if token.Kind == timeformat.UnderYearDay && len(s) > 0 && s[0] == ' ' {
+					s = s[1:]
+				}
+			}
+			yday, s, err = getnum3(s, token.Kind == timeformat.ZeroYearDay && l.python == nil)
+		case timeformat.Hour:
+			hour, s, err = getnum(s, false)
+			if hour < 0 || 24 <= hour {
+				rangeErr = "hour"
+			}
+		case timeformat.UnderHour:
+			if len(s) > 0 && s[0] == ' ' {
+				s = s[1:]
+			}
+			hour, s, err = getnum(s, false)
+			if hour < 0 || 24 <= hour {
+				rangeErr = "hour"
+			}
+		case timeformat.Hour12, timeformat.ZeroHour12, timeformat.UnderHour12:
+			if token.Kind == timeformat.UnderHour12 && len(s) > 0 && s[0] == ' ' {
+				s = s[1:]
+			}
+			hour, s, err = getnum(s, token.Kind == timeformat.ZeroHour12 && l.python == nil)
+			hour12 = true
+			if hour < 0 || 12 < hour {
+				rangeErr = "hour"
+			}
+		case timeformat.Minute, timeformat.ZeroMinute:
+			min, s, err = getnum(s, token.Kind == timeformat.ZeroMinute && l.python == nil)
+			if min < 0 || 60 <= min {
+				rangeErr = "minute"
+			}
+		case timeformat.Second, timeformat.ZeroSecond:
+			sec, s, err = getnum(s, token.Kind == timeformat.ZeroSecond && l.python == nil)
+			if err {
+				break
+			}
+			if sec < 0 || 60 <= sec {
+				rangeErr = "second"
+				break
+			}
+			// a fractional second may follow even if the layout has none
+			if l.python == nil && len(s) >= 2 && commaOrPeriod(s[0]) && isDigit(s, 1) {
+				if next := l.next(i); next == timeformat.FracSecond0 || next == timeformat.FracSecond9 ||
+					next == timeformat.Milliseconds || next == timeformat.Microseconds || next == timeformat.Nanoseconds {
+					break
+				}
+				n := 2
+				for n < len(s) && isDigit(s, n) {
+					n++
+				}
+				nsec, rangeErr, err = parseNanoseconds(s, n)
+				s = s[n:]
+			}
+		case timeformat.PM, timeformat.LowerPM:
+			am, pm := l.words.am, l.words.pm
+			if token.Kind == timeformat.LowerPM {
+				am, pm = strings.ToLower(am), strings.ToLower(pm)
+			}
+			if r != nil {
+				var isPM bool
+				isPM, s, err = r.meridiem(s, value, am, pm)
+				pmSet, amSet = isPM, !isPM
+				break
+			}
+			// strptime ignores case throughout
+			switch {
+			case strings.HasPrefix(s, pm) || l.python != nil && hasPrefixFold(s, pm):
+				pmSet = true
+				s = s[len(pm):]
+			case strings.HasPrefix(s, am) || l.python != nil && hasPrefixFold(s, am):
+				amSet = true
+				s = s[len(am):]
+			default:
+				err = true
+			}
+		case timeformat.ISO8601TZ, timeformat.ISO8601ShortTZ, timeformat.ISO8601ColonTZ,
+			timeformat.ISO8601SecondsTZ, timeformat.ISO8601ColonSecondsTZ:
+			if len(s) >= 1 && s[0] == 'Z' {
+				s = s[1:]
+				utc = true
+				break
+			}
+			zoneOffset, s, err, rangeErr = parseOffset(s, token.Kind)
+		case timeformat.NumTZ, timeformat.NumShortTZ, timeformat.NumColonTZ,
+			timeformat.NumSecondsTZ, timeformat.NumColonSecondsTZ:
+			if l.python != nil {
+				zoneOffset, s, utc, err, rangeErr = parsePythonOffset(s)
+				break
+			}
+			zoneOffset, s, err, rangeErr = parseOffset(s, token.Kind)
+		case timeformat.TZ:
+			if bounds != nil {
+				// one of the names strptime knows, only kept with an offset
+				zoneName, s = s, ""
+				break
+			}
+			if len(s) >= 3 && s[:3] == "UTC" {
+				utc = true
+				s = s[3:]
+				break
+			}
+			n, ok := parseTimeZone(s)
+			if !ok {
+				err = true
+				break
+			}
+			zoneName, s = s[:n], s[n:]
+		case timeformat.FracSecond0:
+			// the exact number of digits given in the layout is required
+			n := len(token.Text)
+			if len(s) < n {
+				err = true
+				break
+			}
+			nsec, rangeErr, err = parseNanoseconds(s, n)
+			s = s[n:]
+		case timeformat.FracSecond9:
+			if len(s) < 2 || !commaOrPeriod(s[0]) || !isDigit(s, 1) {
+				// fractional second omitted
+				break
+			}
+			// take any number of digits, as the Second case would
+			n := 2
+			for n < len(s) && isDigit(s, n) {
+				n++
+			}
+			nsec, rangeErr, err = parseNanoseconds(s, n)
+			s = s[n:]
+		case timeformat.Milliseconds, timeformat.Microseconds, timeformat.Nanoseconds:
+			n := fixedWidth(token, l.words)
+			if l.python != nil && token.Kind == timeformat.Microseconds {
+				// %f takes one to six digits
+				n = 0
+				for n < 6 && isDigit(s, n) {
+					n++
+				}
+				if n == 0 {
+					err = true
+					break
+				}
+			}
+			if len(s) < n {
+				err = true
+				break
+			}
+			if nsec, err = atoi(s[:n]); err || s[0] == '-' || s[0] == '+' {
+				err = true
+				break
+			}
+			for j := n; j < 9; j++ {
+				nsec *= 10
+			}
+			s = s[n:]
+		case timeformat.ISOWeekDay, timeformat.WeekDayNum:
+			// like weekday names, only checked for syntax
+			if !isDigit(s, 0) {
+				err = true
+				break
+			}
+			if wd := int(s[0] - '0'); token.Kind == timeformat.ISOWeekDay && (wd < 1 || wd > 7) ||
+				token.Kind == timeformat.WeekDayNum && wd > 6 {
+				rangeErr = "weekday"
+			}
+			s = s[1:]
+		case timeformat.Unix:
+			n := 0
+			if len(s) > 0 && s[0] == '-' {
+				n++
+			}
+			for n < len(s) && isDigit(s, n) && n < 20 {
+				n++
+			}
+			var x int
+			if x, err = atoi(s[:n]); !err {
+				unix, unixSet = int64(x), true
+				s = s[n:]
+			}
+		default:
+			return fail(field{hold, token}, "token can only be formatted")
+		}
+		if rangeErr != "" {
+			return fail(field{hold, token}, rangeErr+" out of range")
+		}
+		if err || bounds != nil && s != "" {
+			if token.Kind == timeformat.Literal {
+				return fail(field{s, token}, "")
+			}
+			return fail(field{hold, token}, "")
+		}
+		if bounds != nil {
+			s = value[bounds[2*i+3]:]
+		}
+		switch token.Kind {
+		case timeformat.LongMonth, timeformat.Month, timeformat.NumMonth, timeformat.ZeroMonth:
+			monthAt = field{hold, token}
+		case timeformat.Day, timeformat.UnderDay, timeformat.ZeroDay, timeformat.OrdinalDay:
+			dayAt = field{hold, token}
+		case timeformat.UnderYearDay, timeformat.ZeroYearDay:
+			ydayAt = field{hold, token}
+		}
+	}
+	if r != nil {
+		s = r.trimSpace(s, value)
+	}
+	if s != "" {
+		return fail(field{s, timeformat.Token{}}, "extra text")
+	}
+
+	if unixSet {
+		// the instant is given, a zone only picks where to show it
+		t := time.Unix(unix, int64(nsec))
+		switch {
+		case utc:
+			return t.UTC(), nil
+		case zoneOffset != -1:
+			return t.In(time.FixedZone(zoneName, zoneOffset)), nil
+		}
+		return t.In(defaultLocation), nil
+	}
+
+	if l.python != nil {
+		// strptime reads AM/PM only with a 12-hour clock, which is AM
+		// without one
+		amSet, pmSet = hour12 && !pmSet, hour12 && pmSet
+	}
+	if pmSet && hour < 12 {
+		hour += 12
+	} else if amSet && hour == 12 {
+		hour = 0
+	}
+
+	if yday >= 0 && l.python != nil {
+		// strptime takes the date from the year day alone
+		t := time.Date(year, time.January, yday, 0, 0, 0, 0, time.UTC)
+		year, month, day, yday = t.Year(), int(t.Month()), t.Day(), -1
+	}
+
+	// convert yday to day and month
+	if yday >= 0 {
+		var d, m int
+		if isLeap(year) {
+			if yday == 31+29 {
+				m = int(time.February)
+				d = 29
+			} else if yday > 31+29 {
+				yday--
+			}
+		}
+		if yday < 1 || yday > 365 {
+			return fail(ydayAt, "day-of-year out of range")
+		}
+		if m == 0 {
+			m = (yday-1)/31 + 1
+			if daysBefore[m] < yday {
+				m++
+			}
+			d = yday - daysBefore[m-1]
+		}
+		// month and day seen in the value must match the year day
+		if month >= 0 && month != m {
+			return fail(monthAt, "day-of-year does not match month")
+		}
+		month = m
+		if day >= 0 && day != d {
+			return fail(dayAt, "day-of-year does not match day")
+		}
+		day = d
+	} else {
+		if month < 0 {
+			month = int(time.January)
+		}
+		if day < 0 {
+			day = 1
+		}
+	}
+
+	if day < 1 || day > daysIn(month, year) {
+		return fail(dayAt, "day out of range")
+	}
+
+	if utc {
+		return time.Date(year, time.Month(month), day, hour, min, sec, nsec, time.UTC), nil
+	}
+
+	if zoneOffset != -1 {
+		t := time.Date(year, time.Month(month), day, hour, min, sec, nsec, time.UTC)
+		t = t.Add(-time.Duration(zoneOffset) * time.Second)
+
+		// use the local zone if it had the given offset at that time
+		name, offset := t.In(local).Zone()
+		if offset == zoneOffset && (zoneName == "" || name == zoneName) {
+			return t.In(local), nil
+		}
+		return t.In(time.FixedZone(zoneName, zoneOffset)), nil
+	}
+
+	if zoneName != "" && l.python == nil {
+		// resolving an abbreviation needs the zone table of local, which
+		// only the time package can see
+		t := time.Date(year, time.Month(month), day, hour, min, sec, nsec, time.UTC)
+		b := t.AppendFormat(make([]byte, 0, 40), "2006-01-02 15:04:05.000000000 ")
+		return time.ParseInLocation("2006-01-02 15:04:05.000000000 MST", string(append(b, zoneName...)), local)
+	}
+
+	return time.Date(year, time.Month(month), day, hour, min, sec, nsec, defaultLocation), nil
+}
+
+// field records the value text a token was matched against.
+type field struct {
+	at    string
+	token timeformat.Token
+}
+
+// follow returns the literal text right after tokens[i], if any.
+func (l *Layout) follow(i int) string {
+	if i+1 < len(l.tokens) && l.tokens[i+1].Kind == timeformat.Literal {
+		return l.tokens[i+1].Text
+	}
+	return ""
+}
+
+// next returns the kind of the first non-literal token after tokens[i].
+func (l *Layout) next(i int) timeformat.Kind {
+	for _, token := range l.tokens[i+1:] {
+		if token.Kind != timeformat.Literal {
+			return token.Kind
+		}
+	}
+	return timeformat.Literal
+}
+
+// parseOffset parses a numeric zone offset such as "-07:00" in the form
+// given by kind and returns it in seconds east of UTC.
+func parseOffset(s string, kind timeformat.Kind) (offset int, rest string, err bool, rangeErr string) {
+	var sign, hh, mm, ss string
+	switch kind {
+	case timeformat.ISO8601ColonTZ, timeformat.NumColonTZ:
+		if len(s) < 6 || s[3] != ':' {
+			return 0, s, true, ""
+		}
+		sign, hh, mm, ss, rest = s[:1], s[1:3], s[4:6], "00", s[6:]
+	case timeformat.ISO8601ShortTZ, timeformat.NumShortTZ:
+		if len(s) < 3 {
+			return 0, s, true, ""
+		}
+		sign, hh, mm, ss, rest = s[:1], s[1:3], "00", "00", s[3:]
+	case timeformat.ISO8601ColonSecondsTZ, timeformat.NumColonSecondsTZ:
+		if len(s) < 9 || s[3] != ':' || s[6] != ':' {
+			return 0, s, true, ""
+		}
+		sign, hh, mm, ss, rest = s[:1], s[1:3], s[4:6], s[7:9], s[9:]
+	case timeformat.ISO8601SecondsTZ, timeformat.NumSecondsTZ:
+		if len(s) < 7 {
+			return 0, s, true, ""
+		}
+		sign, hh, mm, ss, rest = s[:1], s[1:3], s[3:5], s[5:7], s[7:]
+	default:
+		if len(s) < 5 {
+			return 0, s, true, ""
+		}
+		sign, hh, mm, ss, rest = s[:1], s[1:3], s[3:5], "00", s[5:]
+	}
+	hr, _, err := getnum(hh, true)
+	var m, sec int
+	if !err {
+		m, _, err = getnum(mm, true)
+		if !err {
+			sec, _, err = getnum(ss, true)
+		}
+	}
+
+	// some people do write offsets of 24 hours or 60 minutes or 60 seconds
+	if hr > 24 {
+		rangeErr = "time zone offset hour"
+	}
+	if m > 60 {
+		rangeErr = "time zone offset minute"
+	}
+	if sec > 60 {
+		rangeErr = "time zone offset second"
+	}
+
+	offset = (hr*60+m)*60 + sec
+	switch sign[0] {
+	case '+':
+	case '-':
+		offset = -offset
+	default:
+		err = true
+	}
+	return offset, rest, err, rangeErr
+}
+
+// parseTimeZone returns the length of the zone abbreviation at the start of
+// value. Abbreviations are three to five upper-case letters, with a few
+// special cases, exactly as the time package accepts them.
+func parseTimeZone(value string) (int, bool) {
+	if len(value) < 3 {
+		return 0, false
+	}
+	// ChST and MeST are the only zones with a lower-case letter
+	if len(value) >= 4 && (value[:4] == "ChST" || value[:4] == "MeST") {
+		return 4, true
+	}
+	// GMT may have an hour offset
+	if value[:3] == "GMT" {
+		return 3 + parseSignedOffset(value[3:]), true
+	}
+	// some zones are not named but have a +/-00 form
+	if value[0] == '+' || value[0] == '-' {
+		n := parseSignedOffset(value)
+		return n, n > 0
+	}
+	var upper int
+	for upper < 6 && upper < len(value) && 'A' <= value[upper] && value[upper] <= 'Z' {
+		upper++
+	}
+	switch upper {
+	case 5: // must end in T
+		if value[4] == 'T' {
+			return 5, true
+		}
+	case 4: // must end in T, except one special case
+		if value[3] == 'T' || value[:4] == "WITA" {
+			return 4, true
+		}
+	case 3:
+		return 3, true
+	}
+	return 0, false
+}
+
+// parseSignedOffset returns the length of a signed hour offset in the range
+// -23 through +23 at the start of value, or zero.
+func parseSignedOffset(value string) int {
+	if value == "" || value[0] != '-' && value[0] != '+' {
+		return 0
+	}
+	n := 1
+	x := 0
+	for n < len(value) && isDigit(value, n) {
+		x = x*10 + int(value[n]-'0')
+		if x > 23 {
+			return 0
+		}
+		n++
+	}
+	if n == 1 {
+		return 0
+	}
+	return n
+}
+
+// parseNanoseconds parses the separator and digits in s[:n] as a fraction
+// of a second. Digits past the ninth are truncated.
+func parseNanoseconds(s string, n int) (ns int, rangeErr string, err bool) {
+	if !commaOrPeriod(s[0]) {
+		return 0, "", true
+	}
+	if n > 10 {
+		n = 10
+	}
+	if ns, err = atoi(s[1:n]); err {
+		return 0, "", true
+	}
+	if ns < 0 {
+		return 0, "fractional second", false
+	}
+	for i := n; i < 10; i++ {
+		ns *= 10
+	}
+	return ns, "", false
+}
+
+func commaOrPeriod(b byte) bool {
+	return b == '.' || b == ','
+}
+
+// skip removes the literal prefix from value, treating runs of spaces as
+// equivalent.
+func skip(value, prefix string) (string, bool) {
+	for len(prefix) > 0 {
+		if prefix[0] == ' ' {
+			if len(value) > 0 && value[0] != ' ' {
+				return value, true
+			}
+			prefix = cutspace(prefix)
+			value = cutspace(value)
+			continue
+		}
+		if len(value) == 0 || value[0] != prefix[0] {
+			return value, true
+		}
+		prefix = prefix[1:]
+		value = value[1:]
+	}
+	return value, false
+}
+
+func cutspace(s string) string {
+	for len(s) > 0 && s[0] == ' ' {
+		s = s[1:]
+	}
+	return s
+}
+
+func isDigit(s string, i int) bool {
+	return i < len(s) && '0' <= s[i] && s[i] <= '9'
+}
+
+// getnum parses s[0:1] or s[0:2] (fixed forces s[0:2]) as a decimal number.
+func getnum(s string, fixed bool) (int, string, bool) {
+	if !isDigit(s, 0) {
+		return 0, s, true
+	}
+	if !isDigit(s, 1) {
+		if fixed {
+			return 0, s, true
+		}
+		return int(s[0] - '0'), s[1:], false
+	}
+	return int(s[0]-'0')*10 + int(s[1]-'0'), s[2:], false
+}
+
+// getnum3 parses s[0:1], s[0:2] or s[0:3] (fixed forces s[0:3]) as a
+// decimal number.
+func getnum3(s string, fixed bool) (int, string, bool) {
+	var n, i int
+	for i = 0; i < 3 && isDigit(s, i); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	if i == 0 || fixed && i != 3 {
+		return 0, s, true
+	}
+	return n, s[i:], false
+}
+
+// atoi parses an optionally signed decimal number that fills all of s.
+func atoi(s string) (int, bool) {
+	neg := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "" {
+		return 0, true
+	}
+	x := 0
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s, i) {
+			return 0, true
+		}
+		x = x*10 + int(s[i]-'0')
+	}
+	if neg {
+		x = -x
+	}
+	return x, false
+}
+
+// lookup matches the start of val against tab ignoring case.
+func lookup(tab []string, val string) (int, string, bool) {
+	for i, v := range tab {
+		if len(val) >= len(v) && match(val[:len(v)], v) {
+			return i, val[len(v):], false
+		}
+	}
+	return -1, val, true
+}
+
+// match reports whether s1 and s2 of the same length match ignoring case.
+func match(s1, s2 string) bool {
+	for i := 0; i < len(s1); i++ {
+		c1, c2 := s1[i], s2[i]
+		if c1 != c2 {
+			c1 |= 'a' - 'A'
+			c2 |= 'a' - 'A'
+			if c1 != c2 || c1 < 'a' || c1 > 'z' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// daysBefore[m] counts the days before month m+1 in a non-leap year.
+var daysBefore = [...]int{0, 31, 59, 90, 120, 151, 181, 212, 243, 273, 304, 334, 365}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+func daysIn(month, year int) int {
+	if month == int(time.February) && isLeap(year) {
+		return 29
+	}
+	return daysBefore[month] - daysBefore[month-1]
+}
@@ -3,181 +3,128 @@ package timeparse
 import (
 	"testing"
 	"time"
+
+	"timeformattest/fixture"
 )
 
 
+// TestTimeParse runs the parse cases of testdata/parse.jsonl. A case with a
+// zone parses in that zone, which zone abbreviations are resolved against.
+// Not yet covered: "2006 __ 01 02" with "2021 53 12 31".
+// Not possible: "2006 01 02 15:04:05 MST" with "2021 01 01 12:55:55 Asia/Shanghai".
 func TestTimeParse(t *testing.T) {
-	testData := []struct {
-		Layout string
-		Time   string
-		Want   time.Time
-	}{
-		{
-			Layout: "2006 01 02",
-			Time:   "2021 12 24",
-			Want:   time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 1 2",
-			Time:   "2021 12 24",
-			Want:   time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 1 _2",
-			Time:   "2021 12  5",
-			Want:   time.Date(2021, 12, 5, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 __2",
-			Time:   "2021 145",
-			Want:   time.Date(2021, 5, 25, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 002",
-			Time:   "2021 145",
-			Want:   time.Date(2021, 5, 25, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 __2",
-			Time:   "2021  31",
-			Want:   time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 002",
-			Time:   "2021 031",
-			Want:   time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 January 2",
-			Time:   "2021 February 28",
-			Want:   time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 Jan 2",
-			Time:   "2021 Feb 28",
-			Want:   time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 01 02 Monday",
-			Time:   "2021 10 04 Monday",
-			Want:   time.Date(2021, 10, 04, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 01 02 Monday",
-			Time:   "2021 10 04 Friday",// for parse this is ignored but has to be valid day
-			Want:   time.Date(2021, 10, 04, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 01 02 Mon",
-			Time:   "2021 10 04 Mon",
-			Want:   time.Date(2021, 10, 04, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "2006 01 02 Mon",
-			Time:   "2021 10 04 Fri",// for parse this is ignored but has to be valid day
-			Want:   time.Date(2021, 10, 04, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			Layout: "15:04:05",
-			Time:   "23:55:55",
-			Want:   time.Date(0, 1, 1, 23, 55, 55, 0, time.UTC),
-		},
-		{
-			Layout: "3:04:05 PM",
-			Time:   "12:55:55 PM",
-			Want:   time.Date(0, 1, 1, 12, 55, 55, 0, time.UTC),
-		},
-		{
-			Layout: "03:4:5 pm 06",
-			Time:   "12:55:55 am 20",
-			Want:   time.Date(2020, 1, 1, 0, 55, 55, 0, time.UTC),
-		},
-		{
-			Layout: "03:4:5.000000000 pm 06",
-			Time:   "12:55:55.123456000 am 20",
-			Want:   time.Date(2020, 1, 1, 0, 55, 55, 123456000, time.UTC),
-		},
-		{
-			Layout: "03:4:5.999999999 pm 06",
-			Time:   "12:55:55.123456 am 20",
-			Want:   time.Date(2020, 1, 1, 0, 55, 55, 123456000, time.UTC),
-		},
-		{
-			Layout: "03:4:5,000000000 pm 06",
-			Time:   "12:55:55,123456000 am 20",
-			Want:   time.Date(2020, 1, 1, 0, 55, 55, 123456000, time.UTC),
-		},
-		{
-			Layout: "03:4:5,999999999 pm 06",
-			Time:   "12:55:55,123456 am 20",
-			Want:   time.Date(2020, 1, 1, 0, 55, 55, 123456000, time.UTC),
-		},
-		{
-			Layout: "03:4:5,999999999 pm 06",
-			Time:   "12:55:55.123456 am 20",
-			Want:   time.Date(2020, 1, 1, 0, 55, 55, 123456000, time.UTC),
-		},
-		{
-			Layout: "03:4:5.999999999 pm 06",
-			Time:   "12:55:55,123456 am 20",
-			Want:   time.Date(2020, 1, 1, 0, 55, 55, 123456000, time.UTC),
-		},
-		// {
-		// 	Layout: "2006 __ 01 02",
-		// 	Time:   "2021 53 12 31",
-		// 	Want:   time.Date(2021, 2, 31, 0, 0, 0, 0, time.UTC),
-		// },
+	cases, err := fixture.LoadFile("testdata/parse.jsonl")
+	if err != nil {
+		t.Fatal(err)
 	}
-	for _, test := range testData {
-		got, err := time.Parse(test.Layout, test.Time)
+	for _, test := range cases {
+		want, err := test.Parsed()
+		if err != nil {
+			t.Errorf("%s: %v", test.Output, err)
+			continue
+		}
+		stdParse, parse := time.Parse, Parse
+		if test.Zone != "" {
+			loc := want.Location()
+			stdParse = func(layout, value string) (time.Time, error) { return time.ParseInLocation(layout, value, loc) }
+			parse = func(layout, value string) (time.Time, error) { return Compile(layout).ParseInLocation(value, loc) }
+		}
+		got, err := stdParse(test.Layout, test.Output)
 		if err != nil {
 			t.Error(err)
 		} else {
-			if test.Want != got {
-				t.Errorf("Parse time=%s, layout=%s, want=%v, got=%v", test.Time, test.Layout, test.Want, got)
+			if want != got {
+				t.Errorf("Parse time=%s, layout=%s, want=%v, got=%v", test.Output, test.Layout, want, got)
 			}
 		}
 
+		got, err = parse(test.Layout, test.Output)
+		if err != nil {
+			t.Errorf("%v\n%s", err, err.(*ParseError).Snippet())
+		} else {
+			if want != got {
+				t.Errorf("timeparse.Parse time=%s, layout=%s, want=%v, got=%v", test.Output, test.Layout, want, got)
+			}
+		}
 	}
 }
 
-func TestTimeParseZone(t *testing.T) {
+func TestParseMatchesStdlib(t *testing.T) {
+	testData := []struct {
+		Layout string
+		Time   string
+	}{
+		{Layout: time.RFC3339, Time: "2021-02-20T23:22:21+08:00"},
+		{Layout: time.RFC3339, Time: "2021-02-20T23:22:21Z"},
+		{Layout: time.RFC3339Nano, Time: "2021-02-20T23:22:21.123456789Z"},
+		{Layout: time.RFC3339, Time: "2021-02-20T23:22:21.5Z"},
+		{Layout: time.RFC3339, Time: "2021-02-30T23:22:21Z"},
+		{Layout: time.RFC1123, Time: "Sat, 20 Feb 2021 23:22:21 UTC"},
+		{Layout: time.RFC1123, Time: "Sat, 20 Feb 2021 23:22:21 XYZT"},
+		{Layout: time.RFC1123, Time: "Sat, 20 Feb 2021 23:22:21 GMT+3"},
+		{Layout: time.RFC1123Z, Time: "Sat, 20 Feb 2021 23:22:21 +0100"},
+		{Layout: time.RFC850, Time: "Saturday, 20-Feb-21 23:22:21 CET"},
+		{Layout: time.ANSIC, Time: "Sat Feb  2 23:22:21 2021"},
+		{Layout: time.Kitchen, Time: "3:04PM"},
+		{Layout: time.Kitchen, Time: "13:04PM"},
+		{Layout: time.StampMicro, Time: "Feb  2 23:22:21.123456"},
+		{Layout: time.StampMicro, Time: "Feb  2 23:22:21.1234"},
+		{Layout: "2006 002", Time: "2020 366"},
+		{Layout: "2006 002", Time: "2021 366"},
+		{Layout: "2006 01 002", Time: "2021 02 031"},
+		{Layout: "2006-01-02", Time: "2021-13-01"},
+		{Layout: "2006-01-02", Time: "2021-12-01 extra"},
+		{Layout: "15:04:05", Time: "24:00:00"},
+		{Layout: "15:04:05", Time: "23:59:60"},
+		{Layout: "15:04:05 -07:00:00", Time: "23:59:59 +25:00:00"},
+		{Layout: "Jan _2", Time: "jan  5"},
+		{Layout: "Month 2", Time: "Month 5"},
+		{Layout: "_2006", Time: "_2021"},
+		{Layout: "2006 .000", Time: "2021 .12"},
+		{Layout: "2006 .000", Time: "2021 .-12"},
+	}
+	for _, test := range testData {
+		want, wantErr := time.Parse(test.Layout, test.Time)
+		got, err := Parse(test.Layout, test.Time)
+		if (wantErr == nil) != (err == nil) {
+			t.Errorf("Parse time=%s, layout=%s, want error=%v, got error=%v", test.Time, test.Layout, wantErr, err)
+			continue
+		}
+		if err == nil && (!want.Equal(got) || want.Location().String() != got.Location().String()) {
+			t.Errorf("Parse time=%s, layout=%s, want=%v, got=%v", test.Time, test.Layout, want, got)
+		}
+	}
+}
+
+func TestParseExtended(t *testing.T) {
 	testData := []struct {
 		Layout string
 		Time   string
 		Want   time.Time
 	}{
-		{
-			Layout: "2006 01 02 15:04:05 MST",
-			Time:   "2021 01 01 12:55:55 CET",
-			Want:   time.Date(2021, 1, 1, 12, 55, 55, 0, location("CET")),
-		},
-		{
-			Layout: "2006 01 02 15:04:05Z070000",
-			Time:   "2021 01 01 12:55:55-050000",
-			Want:   time.Date(2021, 1, 1, 12, 55, 55, 0, location("EST")),
-		},
-		{
-			Layout: "2006 01 02 15:04:05-07:00:00",
-			Time:   "2021 01 01 12:55:55-05:00:00",
-			Want:   time.Date(2021, 1, 1, 12, 55, 55, 0, location("EST")),
-		},
-		// {
-		// 	Layout: "2006 01 02 15:04:05 MST",
-		// 	Time:   "2021 01 01 12:55:55 Asia/Shanghai", // not possible
-		// 	Want:   time.Date(2021, 1, 1, 12, 55, 55, 0, location("EST")),
-		// },
+		{Layout: "2006-01-02 {_15}:04", Time: "2021-03-07  9:05", Want: time.Date(2021, 3, 7, 9, 5, 0, 0, time.UTC)},
+		{Layout: "2006-01-02 {_3}:04 PM", Time: "2021-03-07 11:05 PM", Want: time.Date(2021, 3, 7, 23, 5, 0, 0, time.UTC)},
+		{Layout: "2006-01-02 15:04:05.{frac6}", Time: "2021-03-07 14:05:09.120000", Want: time.Date(2021, 3, 7, 14, 5, 9, 120000000, time.UTC)},
+		{Layout: "2006-01-02 15:04:05,{frac3}", Time: "2021-03-07 14:05:09,120", Want: time.Date(2021, 3, 7, 14, 5, 9, 120000000, time.UTC)},
+		{Layout: "2006-01-02 {weekday}", Time: "2021-03-07 7", Want: time.Date(2021, 3, 7, 0, 0, 0, 0, time.UTC)},
+		{Layout: "{unix}", Time: "1615122309", Want: time.Date(2021, 3, 7, 13, 5, 9, 0, time.UTC)},
+		{Layout: "{unix}.{frac9} -0700", Time: "1.500000000 +0100", Want: time.Date(1970, 1, 1, 0, 0, 1, 500000000, time.UTC)},
 	}
 	for _, test := range testData {
-		got, err := time.Parse(test.Layout, test.Time)
+		got, err := Parse(test.Layout, test.Time)
 		if err != nil {
-			t.Error(err)
-		} else {
-			if test.Want.UnixMilli() != got.UnixMilli() {
-				t.Errorf("Parse time=%s, layout=%s, want=%v, got=%v", test.Time, test.Layout, test.Want, got)
-			}
+			t.Errorf("%v\n%s", err, err.(*ParseError).Snippet())
+			continue
 		}
+		if !test.Want.Equal(got) {
+			t.Errorf("Parse time=%s, layout=%s, want=%v, got=%v", test.Time, test.Layout, test.Want, got)
+		}
+	}
 
+	for _, layout := range []string{"{isoyear}-W{isoweek}", "2006 {weekday}", "{unix}"} {
+		if got, err := Parse(layout, "2021-W09 8"); err == nil {
+			t.Errorf("Parse layout=%s, want error, got=%v", layout, got)
+		}
 	}
 }
 
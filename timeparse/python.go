@@ -0,0 +1,240 @@
+package timeparse
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"timeformattest/timeformat"
+)
+
+// CompilePython translates a format of Python's datetime.strptime with
+// timeformat.FromPython and compiles it to parse as strptime does in
+// Python 3.11. Like strptime, it matches the value against a regular
+// expression built from the format, ignoring case, and then converts the
+// matched fields:
+//
+//   - runs of whitespace in the format match any run of whitespace;
+//   - numbers may have fewer digits than strftime writes, so %d matches
+//     "7", "07" and " 7", and %f matches one to six digits; the expression
+//     backtracks, so %Y%m%d reads "202137" as 2021-03-07;
+//   - %z and %:z match Z, and offsets with or without colons and with
+//     optional seconds, such as +0530, +05:30 and +05:30:15;
+//   - %Z matches UTC, GMT and the names of the local zone when the format
+//     was compiled, and the name is only kept with an offset;
+//   - %p only applies to %I, and %I without it is AM;
+//   - %j gives the date by itself, 366 being January 1 of the next year in
+//     other than leap years;
+//   - no fraction is taken after %S unless the format has one.
+//
+// Values without a year are in 1900 and values without an offset parse as
+// UTC, where Python gives a naive time. Formatting the layout gives what
+// Python's strftime does. Diagnostics of the translation are dropped; use
+// timeformat.FromPython to see them.
+//
+// It differs from strptime in that %U, %W, %G and %V are rejected, where
+// strptime uses them with a weekday to find the date; an offset with a
+// fraction of a second is rejected, as a time.Location cannot hold one;
+// digits are ASCII only, where strptime takes any decimal digit; and
+// directives strptime rejects, such as %e or %-d, match like their padded
+// forms.
+func CompilePython(format string) *Layout {
+	layout, _ := timeformat.FromPython(format)
+	l := Compile(layout)
+	l.python = compilePython(l.tokens, l.words)
+	l.fixed = nil
+	return l
+}
+
+// ParsePython is like Python's datetime.strptime, see CompilePython.
+func ParsePython(format, value string) (time.Time, error) {
+	return CompilePython(format).Parse(value)
+}
+
+// pythonRegexp is a layout as the regular expression strptime builds from
+// its format, with a group for each token.
+type pythonRegexp struct {
+	re       *regexp.Regexp
+	patterns []string
+}
+
+// pythonSpace is what \s matches in Python's expressions on str: its
+// whitespace, which includes the information separators.
+const pythonSpace = `[\t-\r\x1c-\x20\x85\xa0\x{1680}\x{2000}-\x{200a}\x{2028}\x{2029}\x{202f}\x{205f}\x{3000}]`
+
+var pythonSpaces = regexp.MustCompile(pythonSpace + "+")
+
+// pythonPatterns are the expressions of strptime's directives by the
+// token kinds FromPython translates them to.
+var pythonPatterns = map[timeformat.Kind]string{
+	timeformat.LongYear:     `\d\d\d\d`,
+	timeformat.ISOYear:      `\d\d\d\d`,
+	timeformat.Year:         `\d\d`,
+	timeformat.ShortISOYear: `\d\d`,
+	timeformat.Century:      `\d\d`,
+	timeformat.NumMonth:     `1[0-2]|0[1-9]|[1-9]`,
+	timeformat.ZeroMonth:    `1[0-2]|0[1-9]|[1-9]`,
+	timeformat.Day:          `3[01]|[12]\d|0[1-9]|[1-9]| [1-9]`,
+	timeformat.UnderDay:     `3[01]|[12]\d|0[1-9]|[1-9]| [1-9]`,
+	timeformat.ZeroDay:      `3[01]|[12]\d|0[1-9]|[1-9]| [1-9]`,
+	timeformat.UnderYearDay: `36[0-6]|3[0-5]\d|[12]\d\d|0[1-9]\d|00[1-9]|[1-9]\d|0[1-9]|[1-9]`,
+	timeformat.ZeroYearDay:  `36[0-6]|3[0-5]\d|[12]\d\d|0[1-9]\d|00[1-9]|[1-9]\d|0[1-9]|[1-9]`,
+	timeformat.Hour:         `2[0-3]|[01]\d|\d`,
+	timeformat.UnderHour:    `2[0-3]|[01]\d|\d`,
+	timeformat.Hour12:       `1[0-2]|0[1-9]|[1-9]`,
+	timeformat.ZeroHour12:   `1[0-2]|0[1-9]|[1-9]`,
+	timeformat.UnderHour12:  `1[0-2]|0[1-9]|[1-9]`,
+	timeformat.Minute:       `[0-5]\d|\d`,
+	timeformat.ZeroMinute:   `[0-5]\d|\d`,
+	timeformat.Second:       `6[01]|[0-5]\d|\d`,
+	timeformat.ZeroSecond:   `6[01]|[0-5]\d|\d`,
+	timeformat.Microseconds: `[0-9]{1,6}`,
+	timeformat.SundayWeek:   `5[0-3]|[0-4]\d|\d`,
+	timeformat.MondayWeek:   `5[0-3]|[0-4]\d|\d`,
+	timeformat.ISOWeek:      `5[0-3]|0[1-9]|[1-4]\d|\d`,
+	timeformat.ISOWeekDay:   `[1-7]`,
+	timeformat.WeekDayNum:   `[0-6]`,
+}
+
+// pythonOffset is the expression of %z, with a Z that must be upper case.
+const pythonOffset = `[+-]\d\d:?[0-5]\d(?::?[0-5]\d(?:\.\d{1,6})?)?|(?-i:Z)`
+
+func compilePython(tokens []timeformat.Token, w *words) *pythonRegexp {
+	p := &pythonRegexp{patterns: make([]string, len(tokens))}
+	for i, token := range tokens {
+		p.patterns[i] = pythonPattern(token, w)
+	}
+	p.re = p.compile(len(tokens))
+	return p
+}
+
+// compile returns the expression of the first n tokens, anchored at the
+// start only: text after a match is an error of its own.
+func (p *pythonRegexp) compile(n int) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^(?i)")
+	for _, pattern := range p.patterns[:n] {
+		b.WriteString("(" + pattern + ")")
+	}
+	return regexp.MustCompile(b.String())
+}
+
+// pythonPattern returns the expression strptime has for a token.
+func pythonPattern(token timeformat.Token, w *words) string {
+	if pattern, ok := pythonPatterns[token.Kind]; ok {
+		return pattern
+	}
+	switch token.Kind {
+	case timeformat.Literal:
+		parts := pythonSpaces.Split(token.Text, -1)
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		return strings.Join(parts, pythonSpace+"+")
+	case timeformat.LongMonth:
+		return pythonNames(w.months)
+	case timeformat.Month:
+		return pythonNames(w.shortMonths)
+	case timeformat.LongWeekDay:
+		return pythonNames(w.days)
+	case timeformat.WeekDay:
+		return pythonNames(w.shortDays)
+	case timeformat.PM, timeformat.LowerPM:
+		return pythonNames([]string{w.am, w.pm})
+	case timeformat.TZ:
+		return pythonNames(localZoneNames())
+	case timeformat.ISO8601TZ, timeformat.ISO8601ShortTZ, timeformat.ISO8601ColonTZ,
+		timeformat.ISO8601SecondsTZ, timeformat.ISO8601ColonSecondsTZ,
+		timeformat.NumTZ, timeformat.NumShortTZ, timeformat.NumColonTZ,
+		timeformat.NumSecondsTZ, timeformat.NumColonSecondsTZ:
+		return pythonOffset
+	}
+	// FromPython writes no other tokens
+	return ""
+}
+
+// pythonNames is an alternation of names, longest first so that none is
+// cut short by a shorter one it starts with.
+func pythonNames(names []string) string {
+	sorted := append([]string(nil), names...)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for i, name := range sorted {
+		sorted[i] = regexp.QuoteMeta(name)
+	}
+	return strings.Join(sorted, "|")
+}
+
+// localZoneNames returns the zone names strptime accepts for %Z: UTC, GMT
+// and the names of the local zone in winter and summer.
+func localZoneNames() []string {
+	names := []string{"UTC", "GMT"}
+	year := time.Now().Year()
+	for _, month := range []time.Month{time.January, time.July} {
+		name, _ := time.Date(year, month, 1, 0, 0, 0, 0, time.Local).Zone()
+		if name != "" && !strings.HasPrefix(name, "+") && !strings.HasPrefix(name, "-") {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// split matches value and returns the bounds of the text of each token,
+// as FindStringSubmatchIndex gives them. If value does not match, it
+// returns the index of the first token that does not, or the number of
+// tokens if text is left after the last one, and the offset where that is.
+func (p *pythonRegexp) split(value string) (bounds []int, token, offset int) {
+	if bounds = p.re.FindStringSubmatchIndex(value); bounds != nil {
+		if bounds[1] == len(value) {
+			return bounds, 0, 0
+		}
+		return nil, len(p.patterns), bounds[1]
+	}
+	for n := len(p.patterns) - 1; n >= 0; n-- {
+		if m := p.compile(n).FindStringIndex(value); m != nil {
+			return nil, n, m[1]
+		}
+	}
+	return nil, 0, 0
+}
+
+// lookupName returns the index of the name in tab that all of s matches
+// ignoring case.
+func lookupName(tab []string, s string) (int, string, bool) {
+	for i, v := range tab {
+		if strings.EqualFold(s, v) {
+			return i, "", false
+		}
+	}
+	return -1, s, true
+}
+
+// parsePythonOffset parses an offset the way strptime reads %z and reports
+// whether it was Z. A colon after the hours requires one after the minutes
+// if there are seconds.
+func parsePythonOffset(s string) (offset int, rest string, utc, err bool, rangeErr string) {
+	if strings.HasPrefix(s, "Z") {
+		return 0, s[1:], true, false, ""
+	}
+	kind := timeformat.NumTZ
+	switch {
+	case len(s) > 3 && s[3] == ':':
+		kind = timeformat.NumColonTZ
+		if len(s) > 6 && s[6] == ':' {
+			kind = timeformat.NumColonSecondsTZ
+		}
+	case isDigit(s, 5) && isDigit(s, 6):
+		kind = timeformat.NumSecondsTZ
+	}
+	offset, rest, err, rangeErr = parseOffset(s, kind)
+	if offset <= -24*60*60 || offset >= 24*60*60 {
+		// a timezone is less than a day from UTC
+		rangeErr = "time zone offset"
+	}
+	return offset, rest, false, err, rangeErr
+}
+
+// hasPrefixFold is strings.HasPrefix ignoring ASCII case.
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && match(s[:len(prefix)], prefix)
+}
@@ -0,0 +1,112 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+
+	"timeformattest/fixture"
+	"timeformattest/timeformat"
+)
+
+// TestPythonFixtures runs the cases of testdata/python.jsonl, recorded with
+// Python's datetime by testdata/python.py.
+func TestPythonFixtures(t *testing.T) {
+	cases, err := fixture.LoadFile("testdata/python.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range cases {
+		if layout, _ := timeformat.FromPython(test.Python); layout != test.Layout {
+			t.Errorf("FromPython %q\nwant=%q\ngot= %q", test.Python, test.Layout, layout)
+		}
+		if test.Time != "" {
+			ts, err := test.Instant()
+			if err != nil {
+				t.Errorf("%s: %v", test.Time, err)
+				continue
+			}
+			if got := timeformat.Format(ts, test.Layout); got != test.Output {
+				t.Errorf("Format time=%s, python=%s, want=%q, got=%q", test.Time, test.Python, test.Output, got)
+			}
+			continue
+		}
+		got, err := ParsePython(test.Python, test.Output)
+		if test.Error != "" {
+			if err == nil {
+				t.Errorf("ParsePython time=%q, python=%s, want error %q, got=%v", test.Output, test.Python, test.Error, got)
+			}
+			continue
+		}
+		want, perr := test.Parsed()
+		if perr != nil {
+			t.Errorf("%s: %v", test.Parse, perr)
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v\n%s", err, err.(*ParseError).Snippet())
+			continue
+		}
+		_, wantOffset := want.Zone()
+		_, gotOffset := got.Zone()
+		if !got.Equal(want) || gotOffset != wantOffset {
+			t.Errorf("ParsePython time=%q, python=%s, want=%v, got=%v", test.Output, test.Python, want, got)
+		}
+	}
+}
+
+func TestParsePython(t *testing.T) {
+	testData := []struct {
+		Format string
+		Value  string
+		Want   time.Time
+		Err    bool
+	}{
+		{Format: "%H:%M%z", Value: "10:00+05:30:15", Want: time.Date(1900, 1, 1, 10, 0, 0, 0, time.FixedZone("", 19815))},
+		{Format: "%H:%M%z", Value: "10:00+053015", Want: time.Date(1900, 1, 1, 10, 0, 0, 0, time.FixedZone("", 19815))},
+		{Format: "%H:%M%:z", Value: "10:00-0130", Want: time.Date(1900, 1, 1, 10, 0, 0, 0, time.FixedZone("", -5400))},
+		{Format: "%S.%f", Value: "01.123", Want: time.Date(1900, 1, 1, 0, 0, 1, 123000000, time.UTC)},
+		{Format: "%I%p", Value: "12Pm", Want: time.Date(1900, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{Format: "%H:%M%z", Value: "10:00z", Err: true},
+		{Format: "%H:%M%z", Value: "10:00+05:3015", Err: true},
+		{Format: "%S", Value: "01.5", Err: true},
+		{Format: "%S.%f", Value: "01.1234567", Err: true},
+		{Format: "%Y", Value: "21", Err: true},
+		{Format: "%m/%d", Value: "2/29", Err: true},
+		// strptime takes a fraction of a second in the offset
+		{Format: "%H:%M%z", Value: "10:00+05:30:15.5", Err: true},
+		{Format: "%d %B", Value: "7 märz", Err: true},
+	}
+	for _, test := range testData {
+		got, err := ParsePython(test.Format, test.Value)
+		_, wantOffset := test.Want.Zone()
+		_, gotOffset := got.Zone()
+		if (err != nil) != test.Err || err == nil && (!got.Equal(test.Want) || gotOffset != wantOffset) {
+			t.Errorf("ParsePython %q %q\nwant=%v err=%v\ngot= %v %v", test.Format, test.Value, test.Want, test.Err, got, err)
+		}
+	}
+}
+
+func TestParsePythonZoneName(t *testing.T) {
+	for _, name := range localZoneNames() {
+		if name == "CET" {
+			t.Skip("CET is the local zone")
+		}
+	}
+	if _, err := ParsePython("%H:%M %Z", "14:05 CET"); err == nil {
+		t.Error("ParsePython accepted a zone name that is neither UTC, GMT nor local")
+	}
+}
+
+func TestParsePythonError(t *testing.T) {
+	_, err := ParsePython("%Y%m%d", "2021x")
+	if e, ok := err.(*ParseError); !ok || e.Offset != 4 || e.Token != "01" {
+		t.Errorf("ParsePython %%Y%%m%%d 2021x: %v", err)
+	}
+}
+
+func TestCompilePythonLocale(t *testing.T) {
+	got, err := CompilePython("%d %B %Y").WithLocale(timeformat.German).Parse("7 MÄRZ 2021")
+	if want := time.Date(2021, 3, 7, 0, 0, 0, 0, time.UTC); err != nil || !got.Equal(want) {
+		t.Errorf("got %v %v, want %v", got, err, want)
+	}
+}
@@ -0,0 +1,84 @@
+package timeparse
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"timeformattest/timeformat"
+)
+
+// TestRoundTripVerdicts checks the fields timeformat.RoundTrip guarantees
+// against formatting and parsing back.
+func TestRoundTripVerdicts(t *testing.T) {
+	layouts := []string{
+		"2006 01 02 15:04:05 MST",
+		"2006 01 02 15:04:05Z070000",
+		"2006 01 02 15:04:05-07:00:00",
+		"2006-01-02T15:04:05.999999999-07:00:00",
+		time.RFC3339Nano,
+		time.RFC1123Z,
+		time.Kitchen,
+		time.StampMilli,
+		"Mon 06 03:04:05.000 PM",
+		"2006-01-02 03:04:05",
+		"2006 002 {_15}:04:05.{frac9}-070000",
+		"{unix}",
+		"{unix}.{frac9}",
+	}
+	times := []time.Time{
+		time.Date(2021, 3, 7, 14, 5, 9, 123456789, time.FixedZone("CET", 3600)),
+		time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2000, 2, 29, 12, 59, 59, 999999999, time.FixedZone("NPT", 5*3600+45*60)),
+		time.Date(1850, 1, 1, 23, 1, 1, 1, time.FixedZone("LMT", -17762)),
+		time.Date(2068, 6, 15, 9, 30, 0, 500, time.FixedZone("", -5*3600-30*60)),
+	}
+	for _, layout := range layouts {
+		r := timeformat.RoundTrip(layout)
+		for _, want := range times {
+			text := timeformat.Format(want, layout)
+			got, err := Parse(layout, text)
+			if name, _ := want.Zone(); name == "" && strings.Contains(layout, "MST") {
+				// formatted as a numeric offset, which MST does not parse
+				continue
+			}
+			if err != nil {
+				t.Errorf("Parse %q %q: %v", layout, text, err)
+				continue
+			}
+			if wantZone, _ := want.Zone(); r.Guaranteed(timeformat.ZoneNameField) {
+				if gotZone, _ := got.Zone(); gotZone != wantZone {
+					t.Errorf("RoundTrip %q zone name of %v, got %v", layout, want, got)
+				}
+			}
+			if strings.Contains(layout, "{unix}") {
+				// a Unix time comes back in UTC
+				got = got.In(want.Location())
+			}
+			for _, f := range []struct {
+				Field     timeformat.Field
+				Want, Got int
+			}{
+				{timeformat.YearField, want.Year(), got.Year()},
+				{timeformat.MonthField, int(want.Month()), int(got.Month())},
+				{timeformat.DayField, want.Day(), got.Day()},
+				{timeformat.HourField, want.Hour(), got.Hour()},
+				{timeformat.MinuteField, want.Minute(), got.Minute()},
+				{timeformat.SecondField, want.Second(), got.Second()},
+				{timeformat.NanosecondField, want.Nanosecond(), got.Nanosecond()},
+			} {
+				if r.Guaranteed(f.Field) && f.Want != f.Got {
+					t.Errorf("RoundTrip %q %s of %v, got %v via %q", layout, f.Field, want, got, text)
+				}
+			}
+			if _, wantOffset := want.Zone(); r.Guaranteed(timeformat.OffsetField) {
+				if _, gotOffset := got.Zone(); gotOffset != wantOffset {
+					t.Errorf("RoundTrip %q offset of %v, got %v via %q", layout, want, got, text)
+				}
+			}
+			if r.Guaranteed(timeformat.InstantField) && !got.Equal(want) {
+				t.Errorf("RoundTrip %q instant %v, got %v via %q", layout, want, got, text)
+			}
+		}
+	}
+}
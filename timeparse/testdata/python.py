@@ -0,0 +1,155 @@
+#!/usr/bin/env python3
+"""Records python.jsonl, the format and parse cases of the Python mode.
+
+Run with Python 3.11 from this directory:
+
+    python3 python.py > python.jsonl
+
+Each case gives the Go layout timeformat.FromPython translates the format
+into; the Go tests check that translation as well as the recorded output.
+"""
+
+import json
+import os
+import random
+import time
+from datetime import datetime, timedelta, timezone
+from zoneinfo import ZoneInfo
+
+# %Z accepts UTC, GMT and the names of the local zone
+os.environ["TZ"] = "UTC"
+time.tzset()
+
+ISO = "%Y-%m-%dT%H:%M:%S.%f%z"
+ISO_LAYOUT = "2006-01-02T15:04:05.{frac6}-0700"
+
+IST = timezone(timedelta(hours=5, minutes=30))
+BRT = timezone(timedelta(hours=-3))
+BERLIN = ZoneInfo("Europe/Berlin")
+
+# (format, layout, instant, zone name for the fixture)
+FORMATS = [
+    (ISO, ISO_LAYOUT, datetime(2021, 3, 7, 14, 5, 9, 123456, IST), ""),
+    (ISO, ISO_LAYOUT, datetime(2021, 3, 7, 14, 5, 9, 0, timezone.utc), ""),
+    (ISO, ISO_LAYOUT, datetime(1999, 12, 31, 23, 59, 59, 999999, BRT), ""),
+    (ISO, ISO_LAYOUT, datetime(2021, 7, 1, 8, 0, 0, 42, BERLIN), "Europe/Berlin"),
+    ("%H:%M:%S.%f", "15:04:05.{frac6}", datetime(2021, 3, 7, 0, 0, 0, 1000, timezone.utc), ""),
+    ("%d/%m/%y %I:%M %p", "02/01/06 03:04 PM", datetime(2021, 3, 7, 14, 5, tzinfo=timezone.utc), ""),
+    ("%d/%m/%y %I:%M %p", "02/01/06 03:04 PM", datetime(2021, 3, 7, 0, 5, tzinfo=timezone.utc), ""),
+    ("%a, %d %b %Y %H:%M:%S %z", "Mon, 02 Jan 2006 15:04:05 -0700", datetime(2021, 3, 7, 14, 5, 9, tzinfo=IST), ""),
+    ("%A %B %j", "Monday January 002", datetime(2020, 12, 31, tzinfo=timezone.utc), ""),
+    ("%c", "Mon Jan _2 15:04:05 2006", datetime(2021, 3, 7, 14, 5, 9, tzinfo=timezone.utc), ""),
+    ("%x %X", "01/02/06 15:04:05", datetime(2021, 3, 7, 14, 5, 9, tzinfo=timezone.utc), ""),
+    ("%Y-%m-%d %H:%M %Z", "2006-01-02 15:04 MST", datetime(2021, 1, 7, 14, 5, tzinfo=BERLIN), "Europe/Berlin"),
+    ("%G-W%V-%u", "{isoyear}-W{isoweek}-{weekday}", datetime(2021, 1, 3, tzinfo=timezone.utc), ""),
+    ("%Y %%", "2006 %", datetime(2021, 3, 7, tzinfo=timezone.utc), ""),
+]
+
+# (format, layout, value)
+PARSES = [
+    (ISO, ISO_LAYOUT, "2021-03-07T14:05:09.123456+0530"),
+    (ISO, ISO_LAYOUT, "2021-03-07T14:05:09.123456+05:30"),
+    (ISO, ISO_LAYOUT, "2021-03-07T14:05:09.5Z"),
+    (ISO, ISO_LAYOUT, "2021-03-07T14:05:09.000001-03:00"),
+    (ISO, ISO_LAYOUT, "2021-03-07T14:05:09.000001-0000"),
+    ("%d/%m/%Y %I:%M %p", "02/01/2006 03:04 PM", "7/3/2021 2:05 pm"),
+    ("%d/%m/%Y %I:%M %p", "02/01/2006 03:04 PM", "07/03/2021 12:05 AM"),
+    ("%d %b %Y", "02 Jan 2006", " 7 MAR 2021"),
+    ("%d %B %Y", "02 January 2006", "7 march 2021"),
+    ("%j %Y", "002 2006", "66 2021"),
+    ("%Y%m%d", "20060102", "20210307"),
+    ("%H:%M:%S", "15:04:05", "9:5:1"),
+    ("%y", "06", "69"),
+    ("%y", "06", "68"),
+    # whitespace in the format matches any run of whitespace
+    ("%Y-%m-%d %H:%M", "2006-01-02 15:04", "2021-03-07\t14:05"),
+    ("%Y-%m-%d %H:%M", "2006-01-02 15:04", "2021-03-07 \u3000\n14:05"),
+    ("%Y-%m-%d %H:%M", "2006-01-02 15:04", "2021-03-0714:05"),
+    ("%b %d", "Jan 02", "Mar  7"),
+    ("%b %d", "Jan 02", "Mar \t 7"),
+    # literals ignore case
+    ("%Y-%m-%dT%H:%M", "2006-01-02T15:04", "2021-03-07t14:05"),
+    # numbers backtrack
+    ("%Y%m%d", "20060102", "202137"),
+    ("%Y%m%d", "20060102", "2021130"),
+    ("%Y%m%d", "20060102", "2021131"),
+    ("%H%M%S", "150405", "959"),
+    # only " 7" has a space
+    ("%d", "02", " 7"),
+    ("%d", "02", " 07"),
+    ("%d", "02", "  7"),
+    # text left over
+    ("%Y %m", "2006 01", "2021 3 "),
+    ("%H", "15", "123"),
+    # %Z is a known name
+    ("%H:%M %Z", "15:04 MST", "14:05 UTC"),
+    ("%H:%M %Z", "15:04 MST", "14:05 gmt"),
+    ("%H:%M %Z", "15:04 MST", "14:05 XYZ"),
+    ("%H:%M %Z %z", "15:04 MST -0700", "14:05 UTC +0530"),
+    # %z
+    ("%H:%M%z", "15:04-0700", "14:05+24:00"),
+    ("%H:%M%z", "15:04-0700", "14:05z"),
+    ("%H:%M%z", "15:04-0700", "14:05-0000"),
+    # %p only with %I, %I alone is AM
+    ("%I", "03", "12"),
+    ("%I %p", "03 PM", "12 am"),
+    ("%H %p", "15 PM", "05 PM"),
+    # %j gives the date
+    ("%j %Y", "002 2006", "366 2021"),
+    ("%j %Y", "002 2006", "366 2020"),
+    ("%m %d %j %Y", "01 02 002 2006", "12 31 060 2021"),
+    ("%j", "002", "0"),
+    # range checks
+    ("%H:%M", "15:04", "24:00"),
+    ("%S", "05", "60"),
+    ("%m/%d", "01/02", "2/29"),
+    ("%d/%m/%Y", "02/01/2006", "29/2/2020"),
+]
+
+# Formats without separators, parsed with numbers of every width, so that
+# backtracking is covered beyond the cases above.
+SWEEPS = [
+    ("%Y%m%d", "20060102", 5, 8),
+    ("%m%d", "0102", 2, 4),
+    ("%H%M%S", "150405", 3, 6),
+    ("%y%j", "06002", 3, 5),
+    ("%I%M%p", "0304PM", 2, 4),
+]
+
+
+def rfc3339(t):
+    s = "%04d" % t.year + t.strftime("-%m-%dT%H:%M:%S")
+    if t.microsecond:
+        s += ".%06d" % t.microsecond
+    offset = t.utcoffset()
+    if offset is None or offset == timedelta(0):
+        return s + "Z"
+    s += t.strftime("%z")
+    return s[:-2] + ":" + s[-2:]
+
+
+def case(**fields):
+    return json.dumps({k: v for k, v in fields.items() if v or k == "layout"},
+                      ensure_ascii=False, separators=(",", ":"))
+
+
+print(case(version=1))
+for fmt, layout, t, zone in FORMATS:
+    print(case(time=rfc3339(t), zone=zone, layout=layout, python=fmt, output=t.strftime(fmt)))
+
+def parse(fmt, layout, value):
+    try:
+        print(case(layout=layout, python=fmt, output=value, parse=rfc3339(datetime.strptime(value, fmt))))
+    except ValueError as e:
+        print(case(layout=layout, python=fmt, output=value, error=str(e)))
+
+
+for fmt, layout, value in PARSES:
+    parse(fmt, layout, value)
+rand = random.Random(1)
+for fmt, layout, shortest, longest in SWEEPS:
+    for _ in range(40):
+        value = "".join(rand.choice("0123456789") for _ in range(rand.randint(shortest, longest)))
+        if fmt.endswith("%p"):
+            value += rand.choice(["AM", "pm"])
+        parse(fmt, layout, value)